@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var allCmd = &cobra.Command{
+	Use:   "all",
+	Short: "Run every configured report in turn",
+	Long: `Run each report one after another, each under its own section header:
+
+  ashby         Ashby applicants by week (needs ASHBY_API_KEY or ashby.api_key)
+  stars         GitHub stars for all.orgs, or github.org
+  incidents     Incidents for all.repos, or github.repo
+  active-users  Datum Cloud active users (needs datum.enabled)
+
+By default every report whose settings are present runs; list report names in
+all.reports in the config file to choose exactly which run.
+
+Reports honor the global --output format. With --output json the reports are
+combined into one document with a "reports" array of {name, title, data} or
+{name, title, error} objects; with --output jsonl each report's lines follow
+the previous report's.
+
+If a report fails, the remaining reports still run; the failures are listed at
+the end and the command exits non-zero.`,
+	Args: cobra.NoArgs,
+	RunE: runAll,
+}
+
+func init() {
+	rootCmd.AddCommand(allCmd)
+}
+
+// allReport is one report run by the all command.
+type allReport struct {
+	name  string
+	title string
+	cmd   *cobra.Command
+	// args returns the report's arguments, or false when its settings are
+	// missing
+	args func() ([]string, bool)
+}
+
+var allReports = []allReport{
+	{"ashby", "Ashby Applicants by Week", applicantsByWeekCmd, func() ([]string, bool) {
+		return nil, envOrConfig("ASHBY_API_KEY") != ""
+	}},
+	{"stars", "GitHub Stars", starsCmd, func() ([]string, bool) {
+		return configList("all.orgs", "github.org")
+	}},
+	{"incidents", "Incidents", incidentsCmd, func() ([]string, bool) {
+		return configList("all.repos", "github.repo")
+	}},
+	{"active-users", "Datum Cloud Active Users", activeUsersCmd, func() ([]string, bool) {
+		return nil, config.GetBool("datum.enabled")
+	}},
+}
+
+// configList returns the config list key, or the single value fallback when
+// the list is not set, and whether either was set.
+func configList(key, fallback string) ([]string, bool) {
+	if list := config.GetStringSlice(key); len(list) > 0 {
+		return list, true
+	}
+	if v := config.GetString(fallback); v != "" {
+		return []string{v}, true
+	}
+	return nil, false
+}
+
+// selectAllReports returns the reports named in all.reports, or every report
+// whose settings are present.
+func selectAllReports() ([]allReport, error) {
+	names := config.GetStringSlice("all.reports")
+	if len(names) == 0 {
+		var selected []allReport
+		for _, r := range allReports {
+			if _, ok := r.args(); ok {
+				selected = append(selected, r)
+			}
+		}
+		if len(selected) == 0 {
+			return nil, fmt.Errorf("nothing to report: configure ashby.api_key, github.org, github.repo, or datum.enabled, or list reports in all.reports")
+		}
+		return selected, nil
+	}
+
+	var selected []allReport
+	for _, name := range names {
+		found := false
+		for _, r := range allReports {
+			if r.name == name {
+				selected = append(selected, r)
+				found = true
+			}
+		}
+		if !found {
+			var known []string
+			for _, r := range allReports {
+				known = append(known, r.name)
+			}
+			return nil, fmt.Errorf("all.reports: unknown report %q (must be one of %s)", name, strings.Join(known, ", "))
+		}
+	}
+	return selected, nil
+}
+
+func runAll(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	reports, err := selectAllReports()
+	if err != nil {
+		return err
+	}
+
+	var output AllJSON
+
+	// Each report adds its metrics; the file is written once at the end
+	if prometheusFile != "" {
+		prometheusPending = &prometheusExport{}
+		defer func() { prometheusPending = nil }()
+	}
+
+	var failures []string
+	for i, r := range reports {
+		// JSON Lines reports stream their records with no headers
+		if !jsonOutput() {
+			if i > 0 {
+				fmt.Fprintln(stdout)
+			}
+			printAllSectionHeader(r.title)
+		}
+
+		// JSON reports are captured so they can be combined into one document
+		var captured bytes.Buffer
+		out := stdout
+		if outputFormat == "json" {
+			stdout = &captured
+		}
+		err := runAllReport(cmd, r)
+		stdout = out
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		data := AllReportJSON{Name: r.name, Title: r.title}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", r.name, err))
+			data.Error = err.Error()
+		} else if captured.Len() > 0 {
+			data.Data = json.RawMessage(bytes.TrimSpace(captured.Bytes()))
+		}
+		output.Reports = append(output.Reports, data)
+	}
+
+	if outputFormat == "json" {
+		b, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, string(b))
+	}
+
+	if prometheusPending != nil && len(prometheusPending.names) > 0 {
+		if err := prometheusPending.write(); err != nil {
+			return err
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("%d of %d reports failed:\n  %s", len(failures), len(reports), strings.Join(failures, "\n  "))
+	}
+	return nil
+}
+
+// runAllReport runs one report's command with its configured arguments and
+// the command's default flags.
+func runAllReport(parent *cobra.Command, r allReport) error {
+	// A report without its settings runs anyway and explains what is
+	// missing, as it would on its own
+	args, _ := r.args()
+	// Parsing no arguments merges in the inherited persistent flags
+	if err := r.cmd.ParseFlags(nil); err != nil {
+		return err
+	}
+	if r.cmd.Args != nil {
+		if err := r.cmd.Args(r.cmd, args); err != nil {
+			return err
+		}
+	}
+	r.cmd.SetContext(parent.Context())
+	return r.cmd.RunE(r.cmd, args)
+}
+
+// printAllSectionHeader introduces a report in the all command's output.
+func printAllSectionHeader(title string) {
+	switch outputFormat {
+	case "csv", "tsv":
+		printRecord(outputFormat, []string{title})
+	case "markdown":
+		fmt.Fprintf(stdout, "## %s\n\n", title)
+	case "template":
+	default:
+		fmt.Fprintf(stdout, "%s\n%s\n\n", title, strings.Repeat("=", len(title)))
+	}
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var approvalsCmd = &cobra.Command{
+	Use:   "approvals [org]/[repo]",
+	Short: "Display pull request approvals per reviewer by week",
+	Long: `Fetch pull request reviews for a repository and count APPROVED reviews per
+reviewer, bucketed by the week the review was submitted.
+
+Only pull requests updated during the reported window are examined.
+Use --top N to show just the N reviewers with the most approvals.
+
+Displays counts for the last 4 completed weeks, or as many as weeks in the
+config file sets.
+
+Requires GITHUB_TOKEN (or github.token in the config file) for API authentication.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runApprovals,
+}
+
+func init() {
+	githubCmd.AddCommand(approvalsCmd)
+	approvalsCmd.Flags().Bool("json", false, "Output in JSON format")
+	approvalsCmd.Flags().Int("top", 0, "Only show the N reviewers with the most approvals (0 = all)")
+}
+
+type githubReview struct {
+	User struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	State       string    `json:"state"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// reviewerApprovals holds one reviewer's approval counts by week.
+type reviewerApprovals struct {
+	Reviewer   string
+	WeekCounts map[string]int
+	Total      int // completed weeks only
+}
+
+func runApprovals(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	args, err := argsOrConfig(args, "github.repo", "an org/repo")
+	if err != nil {
+		return err
+	}
+	repo := args[0]
+	outputJSON := jsonOutput()
+	top, _ := cmd.Flags().GetInt("top")
+	if top < 0 {
+		return fmt.Errorf("--top must not be negative")
+	}
+
+	token := githubToken()
+	if token == "" {
+		return errNoGitHubToken
+	}
+
+	weeks := getLastNWeeks(defaultWeekCount())
+	currentWeek := getCurrentWeekStart()
+	since, _ := parseWeekStart(weeks[0])
+
+	progressf("Fetching pull requests for %s...\n", repo)
+	pulls, err := fetchPullsUpdatedSince(ctx, token, repo, "all", since)
+	if err != nil {
+		return fmt.Errorf("failed to fetch pull requests: %w", err)
+	}
+
+	progressf("Fetching reviews for %d pull requests...\n", len(pulls))
+	reviewers := make(map[string]*reviewerApprovals)
+	inWindow := make(map[string]bool)
+	for _, week := range weeks {
+		inWindow[week] = true
+	}
+	inWindow[currentWeek] = true
+
+	for _, pr := range pulls {
+		reviews, err := fetchPullReviews(ctx, token, repo, pr.Number)
+		if err != nil {
+			return fmt.Errorf("failed to fetch reviews for #%d: %w", pr.Number, err)
+		}
+		for _, review := range reviews {
+			if review.State != "APPROVED" || review.User.Login == "" {
+				continue
+			}
+			week := getWeekStart(review.SubmittedAt)
+			if !inWindow[week] {
+				continue
+			}
+			r, ok := reviewers[review.User.Login]
+			if !ok {
+				r = &reviewerApprovals{Reviewer: review.User.Login, WeekCounts: make(map[string]int)}
+				reviewers[review.User.Login] = r
+			}
+			r.WeekCounts[week]++
+			if week != currentWeek {
+				r.Total++
+			}
+		}
+	}
+
+	// Leaderboard order: most approvals first, then by name
+	var ranked []*reviewerApprovals
+	for _, r := range reviewers {
+		ranked = append(ranked, r)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Total != ranked[j].Total {
+			return ranked[i].Total > ranked[j].Total
+		}
+		return ranked[i].Reviewer < ranked[j].Reviewer
+	})
+	if top > 0 && len(ranked) > top {
+		ranked = ranked[:top]
+	}
+
+	if wantTemplateData() {
+		data := newTemplateData("github approvals", repo, weeks, currentWeek)
+		for _, r := range ranked {
+			data.addRow(r.Reviewer, "", r.WeekCounts)
+		}
+		if err := data.output(); err != nil || outputFormat == "template" {
+			return err
+		}
+	}
+
+	if outputJSON {
+		return printApprovalsJSON(repo, ranked, weeks, currentWeek)
+	}
+
+	printTableTitle("Approvals for %s (%s)", repo, describeWeeks(weeks))
+
+	table := newWeeklyTable(25, 10, weeks)
+	table.printHeader("Reviewer", currentWeek)
+	table.printSeparator(currentWeek)
+
+	weekTotals := make(map[string]int)
+	for _, r := range ranked {
+		table.printRow(r.Reviewer, r.WeekCounts, currentWeek)
+		for week, count := range r.WeekCounts {
+			weekTotals[week] += count
+		}
+	}
+
+	table.printSeparator(currentWeek)
+	table.printTotalsRow("Total", weekTotals, currentWeek)
+
+	return nil
+}
+
+// fetchPullReviews returns all reviews submitted on a pull request.
+func fetchPullReviews(ctx context.Context, token, repo string, number int) ([]githubReview, error) {
+	var allReviews []githubReview
+
+	client := newHTTPClient()
+
+	// Follow the Link header until there is no next page
+	next := fmt.Sprintf("https://api.github.com/repos/%s/pulls/%d/reviews?per_page=100", repo, number)
+	for next != "" {
+		body, nextURL, err := githubRequestPage(ctx, client, token, next)
+		if errors.Is(err, errGitHubNotFound) {
+			return nil, fmt.Errorf("pull request not found: %s#%d", repo, number)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var reviews []githubReview
+		if err := json.Unmarshal(body, &reviews); err != nil {
+			return nil, err
+		}
+
+		writeRaw(body)
+
+		allReviews = append(allReviews, reviews...)
+		next = nextURL
+	}
+
+	return allReviews, nil
+}
+
+func printApprovalsJSON(repo string, ranked []*reviewerApprovals, weeks []string, currentWeek string) error {
+	output := ApprovalsJSON{Repository: repo, Reviewers: []ApprovalsReviewerJSON{}}
+	for _, r := range ranked {
+		data := ApprovalsReviewerJSON{
+			Reviewer:    r.Reviewer,
+			CurrentWeek: WeekCountJSON{WeekEnding: weekStartToEnd(currentWeek), Count: r.WeekCounts[currentWeek]},
+			Total:       r.Total,
+		}
+		for _, week := range weeks {
+			data.Weeks = append(data.Weeks, WeekCountJSON{WeekEnding: weekStartToEnd(week), Count: r.WeekCounts[week]})
+		}
+		output.Reviewers = append(output.Reviewers, data)
+	}
+
+	return printJSON(output)
+}
@@ -1,25 +1,33 @@
 package cmd
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 )
 
-const ashbyAPIBase = "https://api.ashbyhq.com"
+const defaultAshbyAPIBase = "https://api.ashbyhq.com"
+
+// ashbyAPIBase is the Ashby API root, overridable with --api-base or
+// ASHBY_API_BASE (e.g. to point at a mock server).
+var ashbyAPIBase string
 
 type ashbyApplication struct {
 	ID        string    `json:"id"`
 	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
 	Status    string    `json:"status"`
 	Candidate struct {
 		ID   string `json:"id"`
@@ -29,10 +37,81 @@ type ashbyApplication struct {
 		ID    string `json:"id"`
 		Title string `json:"title"`
 	} `json:"job"`
+	ArchiveReason *struct {
+		ID         string `json:"id"`
+		Text       string `json:"text"`
+		ReasonType string `json:"reasonType"`
+	} `json:"archiveReason"`
+	CurrentInterviewStage *struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+		Type  string `json:"type"`
+	} `json:"currentInterviewStage"`
+	Source *struct {
+		ID         string `json:"id"`
+		Title      string `json:"title"`
+		SourceType *struct {
+			ID    string `json:"id"`
+			Title string `json:"title"`
+		} `json:"sourceType"`
+	} `json:"source"`
+}
+
+// sourceLabels returns the application's source and source type (e.g.
+// "LinkedIn" and "Job Board"), with placeholders when Ashby has none.
+func (a *ashbyApplication) sourceLabels() (source, sourceType string) {
+	source, sourceType = "No Source", "Other"
+	if a.Source == nil {
+		return source, sourceType
+	}
+	if a.Source.Title != "" {
+		source = a.Source.Title
+	}
+	if a.Source.SourceType != nil && a.Source.SourceType.Title != "" {
+		sourceType = a.Source.SourceType.Title
+	}
+	return source, sourceType
+}
+
+// ashbyStatus is the status every Ashby response carries. A failed request
+// has success false and explains why in errors and, on newer endpoints,
+// errorInfo.
+type ashbyStatus struct {
+	Success   bool     `json:"success"`
+	Errors    []string `json:"errors"`
+	ErrorInfo *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"errorInfo"`
+}
+
+// err returns nil for a successful response, or an error with Ashby's
+// messages, e.g. "API returned success=false: invalid_input: unknown field".
+func (s ashbyStatus) err() error {
+	if s.Success {
+		return nil
+	}
+	messages := append([]string{}, s.Errors...)
+	if s.ErrorInfo != nil {
+		info := s.ErrorInfo.Code
+		if s.ErrorInfo.Message != "" {
+			if info != "" {
+				info += ": "
+			}
+			info += s.ErrorInfo.Message
+		}
+		if info != "" {
+			messages = append(messages, info)
+		}
+	}
+	if len(messages) == 0 {
+		return fmt.Errorf("API returned success=false")
+	}
+	return fmt.Errorf("API returned success=false: %s", strings.Join(messages, "; "))
 }
 
 type ashbyApplicationListResponse struct {
-	Success           bool               `json:"success"`
+	ashbyStatus
 	Results           []ashbyApplication `json:"results"`
 	MoreDataAvailable bool               `json:"moreDataAvailable"`
 	NextCursor        string             `json:"nextCursor"`
@@ -46,7 +125,7 @@ type ashbyJob struct {
 }
 
 type ashbyJobListResponse struct {
-	Success           bool       `json:"success"`
+	ashbyStatus
 	Results           []ashbyJob `json:"results"`
 	MoreDataAvailable bool       `json:"moreDataAvailable"`
 	NextCursor        string     `json:"nextCursor"`
@@ -58,7 +137,7 @@ type ashbyDepartment struct {
 }
 
 type ashbyDepartmentListResponse struct {
-	Success           bool              `json:"success"`
+	ashbyStatus
 	Results           []ashbyDepartment `json:"results"`
 	MoreDataAvailable bool              `json:"moreDataAvailable"`
 	NextCursor        string            `json:"nextCursor"`
@@ -67,19 +146,62 @@ type ashbyDepartmentListResponse struct {
 type ashbyJobInfo struct {
 	Title      string
 	Department string
+	Status     string
 }
 
+// ashbyJobStatuses are the accepted --job-status values, besides "all".
+var ashbyJobStatuses = []string{"Open", "Closed", "Draft", "Archived"}
+
+// ashbyApplicationStatuses are the accepted --status values.
+var ashbyApplicationStatuses = []string{"Active", "Hired", "Archived", "Lead"}
+
 type ashbyJobMetrics struct {
+	Instance   string // set only when breaking out multiple instances
 	Department string
 	Title      string
+	Source     string // set only with --by-source
+	SourceType string // set only with --by-source
 	WeekCounts map[string]int
 }
 
+// group returns the department heading for the job, qualified with the
+// instance label when instances are broken out.
+func (m *ashbyJobMetrics) group() string {
+	if m.Instance == "" {
+		return m.Department
+	}
+	return fmt.Sprintf("%s [%s]", m.Department, m.Instance)
+}
+
+// ashbyInstance is one Ashby account to pull metrics from.
+type ashbyInstance struct {
+	Label  string
+	APIKey string
+}
+
 func init() {
 	rootCmd.AddCommand(ashbyCmd)
 	ashbyCmd.AddCommand(applicantsByWeekCmd)
+	ashbyCmd.PersistentFlags().IntVar(&ashbyMaxPages, "max-pages", 10000, "Maximum pages to fetch per Ashby list request (0 = no limit)")
+	ashbyCmd.PersistentFlags().StringVar(&ashbyAPIBase, "api-base", "", "Ashby API base URL (default: $ASHBY_API_BASE or "+defaultAshbyAPIBase+")")
 	applicantsByWeekCmd.Flags().Bool("json", false, "Output in JSON format")
-	applicantsByWeekCmd.Flags().Bool("histo", false, "Display histogram of last 6 months")
+	applicantsByWeekCmd.Flags().Bool("csv", false, "Output in CSV format, one row per job")
+	applicantsByWeekCmd.Flags().Bool("histo", false, "Display a histogram of weekly totals (last 6 months; see --histo-weeks)")
+	applicantsByWeekCmd.Flags().Bool("histo-by-job", false, "Display a one-line histogram of the last 6 months for each job (see --histo-weeks)")
+	applicantsByWeekCmd.Flags().Bool("average", false, "Add an Average row with the mean weekly total over the completed weeks")
+	applicantsByWeekCmd.Flags().Bool("include-current", false, "Add the current partial week to the end of histograms")
+	addHistogramFlags(applicantsByWeekCmd)
+	applicantsByWeekCmd.Flags().Bool("raw", false, "Write unprocessed API responses to stdout instead of a report")
+	applicantsByWeekCmd.Flags().Int("weeks", 4, "Number of completed weeks to show (1-52; histograms default to --histo-weeks)")
+	applicantsByWeekCmd.Flags().String("since", "", "First week to show (YYYY-MM-DD, now-4w, last-week, ...)")
+	applicantsByWeekCmd.Flags().String("until", "", "Last week to show (YYYY-MM-DD, now, last-week, ...)")
+	applicantsByWeekCmd.Flags().String("job-status", "Open", "Only count jobs with this status: Open, Closed, Draft, Archived, or all")
+	applicantsByWeekCmd.Flags().StringArray("department", nil, "Only show jobs in this department (case-insensitive, repeatable)")
+	applicantsByWeekCmd.Flags().StringArray("status", nil, "Only count applications with this status: Active, Hired, Archived, or Lead (case-insensitive, repeatable; default: all statuses)")
+	applicantsByWeekCmd.Flags().StringArray("api-key", nil, "Ashby API key as [label=]key; repeat to combine instances (default: $ASHBY_API_KEY)")
+	applicantsByWeekCmd.Flags().Bool("by-instance", false, "Break out jobs per Ashby instance instead of merging them")
+	applicantsByWeekCmd.Flags().Bool("by-source", false, "Group applicants by application source (job board, referral, agency, ...) instead of by job")
+	applicantsByWeekCmd.Flags().Bool("warn-unknown", false, "Warn about applications referencing jobs missing from job.list")
 }
 
 var ashbyCmd = &cobra.Command{
@@ -91,19 +213,85 @@ var ashbyCmd = &cobra.Command{
 var applicantsByWeekCmd = &cobra.Command{
 	Use:   "applicants-by-week",
 	Short: "Show applicants by week for each job",
-	Long:  "Fetches all applications and groups them by job and week",
-	Run:   runApplicantsByWeek,
+	Long: `Fetches all applications and groups them by job and week.
+
+Only applications created since the start of the displayed window are
+fetched; use --since and --until to choose the window.
+
+Only applications for Open jobs are counted by default; use --job-status to
+choose another status, or all. Applications for jobs missing from job.list are
+always kept since their status is unknown.
+
+Applications of every status are counted unless --status is given; repeat it
+(e.g. --status Active --status Hired) to keep several. It combines with
+--job-status, --department, and --by-source.
+
+Repeat --api-key (as label=key) to combine several Ashby instances; jobs are
+merged by department and title unless --by-instance is set.`,
+	RunE: runApplicantsByWeek,
 }
 
-func loadAshbyEnv(envVar string) string {
-	v := os.Getenv(envVar)
+// loadAshbyEnv returns envVar, falling back to its config file key (see
+// envConfigKeys), and returns an error when neither is set.
+func loadAshbyEnv(envVar string) (string, error) {
+	v := envOrConfig(envVar)
 	if v == "" {
-		log.Fatalf("must set %v", envVar)
+		if key, ok := envConfigKeys[envVar]; ok {
+			return "", fmt.Errorf("must set %v (or %v in the config file)", envVar, key)
+		}
+		return "", fmt.Errorf("must set %v", envVar)
+	}
+	return v, nil
+}
+
+// resolveAshbyAPIBase applies ASHBY_API_BASE when --api-base is not given,
+// falls back to the public API, and checks that the result is an http(s) URL.
+func resolveAshbyAPIBase() error {
+	if ashbyAPIBase == "" {
+		ashbyAPIBase = os.Getenv("ASHBY_API_BASE")
+	}
+	if ashbyAPIBase == "" {
+		ashbyAPIBase = defaultAshbyAPIBase
+		return nil
 	}
-	return v
+	u, err := url.Parse(ashbyAPIBase)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid Ashby API base %q (must be an http or https URL)", ashbyAPIBase)
+	}
+	ashbyAPIBase = strings.TrimRight(ashbyAPIBase, "/")
+	return nil
 }
 
-func ashbyRequest(apiKey, endpoint string, body map[string]interface{}) ([]byte, error) {
+// loadAshbyInstances returns the instances given with --api-key, or a single
+// instance using ASHBY_API_KEY when the flag is not set. Keys may be prefixed
+// with "label=" to name the instance in reports.
+func loadAshbyInstances(cmd *cobra.Command) ([]ashbyInstance, error) {
+	keys, _ := cmd.Flags().GetStringArray("api-key")
+	if len(keys) == 0 {
+		apiKey, err := loadAshbyEnv("ASHBY_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+		return []ashbyInstance{{Label: "default", APIKey: apiKey}}, nil
+	}
+
+	var instances []ashbyInstance
+	seen := make(map[string]bool)
+	for i, key := range keys {
+		inst := ashbyInstance{Label: fmt.Sprintf("instance%d", i+1), APIKey: key}
+		if label, apiKey, ok := strings.Cut(key, "="); ok && label != "" && apiKey != "" && !strings.HasPrefix(apiKey, "=") {
+			inst = ashbyInstance{Label: label, APIKey: apiKey}
+		}
+		if seen[inst.Label] {
+			return nil, fmt.Errorf("duplicate Ashby instance label %q", inst.Label)
+		}
+		seen[inst.Label] = true
+		instances = append(instances, inst)
+	}
+	return instances, nil
+}
+
+func ashbyRequest(ctx context.Context, apiKey, endpoint string, body map[string]interface{}) ([]byte, error) {
 	auth := base64.StdEncoding.EncodeToString([]byte(apiKey + ":"))
 
 	jsonBody, err := json.Marshal(body)
@@ -111,44 +299,165 @@ func ashbyRequest(apiKey, endpoint string, body map[string]interface{}) ([]byte,
 		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", ashbyAPIBase+"/"+endpoint, strings.NewReader(string(jsonBody)))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	if dryRun {
+		dryRunf("POST %s/%s %s", ashbyAPIBase, endpoint, jsonBody)
+		return dryRunAshbyResponse, nil
 	}
 
-	req.Header.Set("Authorization", "Basic "+auth)
-	req.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+	cachePath := ashbyCachePath(apiKey, endpoint, jsonBody)
+	if cached, ok := readAshbyCache(cachePath); ok {
+		logger.Debug("ashby cache hit", "endpoint", endpoint, "path", cachePath)
+		writeRaw(cached)
+		return cached, nil
 	}
-	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+	client := newHTTPClient()
+
+	// Rate-limited requests (429) are retried, honoring Retry-After
+	var resp *http.Response
+	var respBody []byte
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, "POST", ashbyAPIBase+"/"+endpoint, strings.NewReader(string(jsonBody)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		req.Header.Set("Authorization", "Basic "+auth)
+		req.Header.Set("Content-Type", "application/json")
+
+		start := time.Now()
+		resp, err = client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("request failed: %w", err)
+		}
+
+		respBody, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+		logger.Debug("ashby request", "endpoint", endpoint, "cursor", body["cursor"], "status", resp.StatusCode, "bytes", len(respBody), "elapsed", time.Since(start).Round(time.Millisecond))
+
+		if resp.StatusCode != http.StatusTooManyRequests || attempt == maxRetries {
+			break
+		}
+		delay := retryDelay(resp, attempt)
+		stderrf("Rate limited by Ashby (%s); retrying in %s (%d/%d)\n", endpoint, delay, attempt+1, maxRetries)
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
 	}
 
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("API error: %d %s - %s", resp.StatusCode, resp.Status, string(respBody))
 	}
 
+	// A 200 can still carry success: false; leave those for the caller to
+	// report rather than replaying them from the cache
+	var status ashbyStatus
+	if json.Unmarshal(respBody, &status) == nil && status.Success {
+		writeAshbyCache(cachePath, respBody)
+	}
+	writeRaw(respBody)
 	return respBody, nil
 }
 
-func fetchAllApplications(apiKey string) ([]ashbyApplication, error) {
+// ashbyMaxPages caps how many pages one Ashby list fetch may request.
+var ashbyMaxPages int
+
+// ashbyPager guards a cursor-paginated fetch against responses that would
+// otherwise loop forever: more data reported without a cursor, the same
+// cursor returned twice in a row, or more than --max-pages pages.
+type ashbyPager struct {
+	pages  int
+	cursor string
+}
+
+// next is called when a page reports more data, with the cursor for the
+// following page. It returns an error if fetching should stop.
+func (p *ashbyPager) next(cursor string) error {
+	p.pages++
+	if ashbyMaxPages > 0 && p.pages >= ashbyMaxPages {
+		return fmt.Errorf("stopped after %d pages (raise --max-pages if this is expected)", p.pages)
+	}
+	if cursor == "" {
+		return fmt.Errorf("API reported more data but returned no cursor")
+	}
+	if cursor == p.cursor {
+		return fmt.Errorf("API returned the same cursor twice in a row")
+	}
+	p.cursor = cursor
+	logger.Debug("next page", "page", p.pages+1, "cursor", cursor)
+	return nil
+}
+
+// fetchAshbyData loads an instance's departments and then its jobs, while its
+// applications (created after createdAfter, if set) load concurrently since
+// they do not depend on either. The first failure cancels the other fetch and
+// is returned.
+func fetchAshbyData(ctx context.Context, apiKey string, createdAfter time.Time) (map[string]string, map[string]ashbyJobInfo, []ashbyApplication, error) {
+	var (
+		departments  map[string]string
+		jobs         map[string]ashbyJobInfo
+		applications []ashbyApplication
+	)
+	g, ctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		progressf("Fetching departments...\n")
+		var err error
+		departments, err = fetchAllDepartments(ctx, apiKey)
+		if err != nil {
+			return fmt.Errorf("failed to fetch departments: %w", err)
+		}
+		progressf("Found %d departments\n", len(departments))
+
+		progressf("Fetching jobs...\n")
+		jobs, err = fetchAllJobs(ctx, apiKey, departments)
+		if err != nil {
+			return fmt.Errorf("failed to fetch jobs: %w", err)
+		}
+		progressf("Found %d jobs\n", len(jobs))
+		return nil
+	})
+
+	g.Go(func() error {
+		progressf("Fetching applications...\n")
+		var err error
+		applications, err = fetchAllApplications(ctx, apiKey, createdAfter)
+		if err != nil {
+			return fmt.Errorf("failed to fetch applications: %w", err)
+		}
+		progressf("Found %d applications\n", len(applications))
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, nil, nil, err
+	}
+	return departments, jobs, applications, nil
+}
+
+// fetchAllApplications returns every application, or only those created after
+// createdAfter when it is non-zero. The API has no upper bound filter, so
+// callers discard applications past the end of their window themselves.
+func fetchAllApplications(ctx context.Context, apiKey string, createdAfter time.Time) ([]ashbyApplication, error) {
 	var applications []ashbyApplication
 	var cursor string
+	var pager ashbyPager
+	progress := newFetchProgress("applications")
+	defer progress.done()
 
 	for {
 		body := map[string]interface{}{"limit": 100}
 		if cursor != "" {
 			body["cursor"] = cursor
 		}
+		if !createdAfter.IsZero() {
+			body["createdAfter"] = createdAfter.UnixMilli()
+		}
 
-		respBody, err := ashbyRequest(apiKey, "application.list", body)
+		respBody, err := ashbyRequest(ctx, apiKey, "application.list", body)
 		if err != nil {
 			return nil, err
 		}
@@ -158,15 +467,19 @@ func fetchAllApplications(apiKey string) ([]ashbyApplication, error) {
 			return nil, fmt.Errorf("failed to parse response: %w", err)
 		}
 
-		if !response.Success {
-			return nil, fmt.Errorf("API returned success=false")
+		if err := response.err(); err != nil {
+			return nil, err
 		}
 
 		applications = append(applications, response.Results...)
+		progress.page(len(response.Results))
 
 		if !response.MoreDataAvailable {
 			break
 		}
+		if err := pager.next(response.NextCursor); err != nil {
+			return nil, err
+		}
 		cursor = response.NextCursor
 
 		// Rate limiting
@@ -176,9 +489,12 @@ func fetchAllApplications(apiKey string) ([]ashbyApplication, error) {
 	return applications, nil
 }
 
-func fetchAllDepartments(apiKey string) (map[string]string, error) {
+func fetchAllDepartments(ctx context.Context, apiKey string) (map[string]string, error) {
 	departments := make(map[string]string)
 	var cursor string
+	var pager ashbyPager
+	progress := newFetchProgress("departments")
+	defer progress.done()
 
 	for {
 		body := map[string]interface{}{"limit": 100}
@@ -186,7 +502,7 @@ func fetchAllDepartments(apiKey string) (map[string]string, error) {
 			body["cursor"] = cursor
 		}
 
-		respBody, err := ashbyRequest(apiKey, "department.list", body)
+		respBody, err := ashbyRequest(ctx, apiKey, "department.list", body)
 		if err != nil {
 			return nil, err
 		}
@@ -196,17 +512,21 @@ func fetchAllDepartments(apiKey string) (map[string]string, error) {
 			return nil, fmt.Errorf("failed to parse response: %w", err)
 		}
 
-		if !response.Success {
-			return nil, fmt.Errorf("API returned success=false")
+		if err := response.err(); err != nil {
+			return nil, err
 		}
 
 		for _, dept := range response.Results {
 			departments[dept.ID] = dept.Name
 		}
+		progress.page(len(response.Results))
 
 		if !response.MoreDataAvailable {
 			break
 		}
+		if err := pager.next(response.NextCursor); err != nil {
+			return nil, err
+		}
 		cursor = response.NextCursor
 
 		time.Sleep(100 * time.Millisecond)
@@ -215,9 +535,12 @@ func fetchAllDepartments(apiKey string) (map[string]string, error) {
 	return departments, nil
 }
 
-func fetchAllJobs(apiKey string, departments map[string]string) (map[string]ashbyJobInfo, error) {
+func fetchAllJobs(ctx context.Context, apiKey string, departments map[string]string) (map[string]ashbyJobInfo, error) {
 	jobs := make(map[string]ashbyJobInfo)
 	var cursor string
+	var pager ashbyPager
+	progress := newFetchProgress("jobs")
+	defer progress.done()
 
 	for {
 		body := map[string]interface{}{"limit": 100}
@@ -225,7 +548,7 @@ func fetchAllJobs(apiKey string, departments map[string]string) (map[string]ashb
 			body["cursor"] = cursor
 		}
 
-		respBody, err := ashbyRequest(apiKey, "job.list", body)
+		respBody, err := ashbyRequest(ctx, apiKey, "job.list", body)
 		if err != nil {
 			return nil, err
 		}
@@ -235,8 +558,8 @@ func fetchAllJobs(apiKey string, departments map[string]string) (map[string]ashb
 			return nil, fmt.Errorf("failed to parse response: %w", err)
 		}
 
-		if !response.Success {
-			return nil, fmt.Errorf("API returned success=false")
+		if err := response.err(); err != nil {
+			return nil, err
 		}
 
 		for _, job := range response.Results {
@@ -244,12 +567,16 @@ func fetchAllJobs(apiKey string, departments map[string]string) (map[string]ashb
 			if deptName == "" {
 				deptName = "No Department"
 			}
-			jobs[job.ID] = ashbyJobInfo{Title: job.Title, Department: deptName}
+			jobs[job.ID] = ashbyJobInfo{Title: job.Title, Department: deptName, Status: job.Status}
 		}
+		progress.page(len(response.Results))
 
 		if !response.MoreDataAvailable {
 			break
 		}
+		if err := pager.next(response.NextCursor); err != nil {
+			return nil, err
+		}
 		cursor = response.NextCursor
 
 		time.Sleep(100 * time.Millisecond)
@@ -258,116 +585,388 @@ func fetchAllJobs(apiKey string, departments map[string]string) (map[string]ashb
 	return jobs, nil
 }
 
-func runApplicantsByWeek(cmd *cobra.Command, args []string) {
-	apiKey := loadAshbyEnv("ASHBY_API_KEY")
-	outputJSON, _ := cmd.Flags().GetBool("json")
+func runApplicantsByWeek(cmd *cobra.Command, args []string) error {
+	instances, err := loadAshbyInstances(cmd)
+	if err != nil {
+		return err
+	}
+	byInstance, _ := cmd.Flags().GetBool("by-instance")
+	bySource, _ := cmd.Flags().GetBool("by-source")
+	outputJSON := jsonOutput()
 	outputHisto, _ := cmd.Flags().GetBool("histo")
-
-	fmt.Fprintln(os.Stderr, "Fetching departments...")
-	departments, err := fetchAllDepartments(apiKey)
+	outputCSV := delimitedFormat()
+	outputHistoByJob, _ := cmd.Flags().GetBool("histo-by-job")
+	includeCurrent, _ := cmd.Flags().GetBool("include-current")
+	showAverage, _ := cmd.Flags().GetBool("average")
+	warnUnknown, _ := cmd.Flags().GetBool("warn-unknown")
+	since, _ := cmd.Flags().GetString("since")
+	until, _ := cmd.Flags().GetString("until")
+	numWeeks := weeksFlag(cmd)
+	departmentFilter, _ := cmd.Flags().GetStringArray("department")
+	jobStatus, _ := cmd.Flags().GetString("job-status")
+	statusFilter, _ := cmd.Flags().GetStringArray("status")
+	outputRaw := enableRawOutput(cmd)
+
+	if numWeeks < 1 || numWeeks > 52 {
+		return fmt.Errorf("--weeks must be between 1 and 52, got %d", numWeeks)
+	}
+	if bySource && outputHistoByJob {
+		return fmt.Errorf("--by-source cannot be combined with --histo-by-job")
+	}
+	histoHeight, histoWeekCount, err := histogramFlags(cmd)
 	if err != nil {
-		log.Fatalf("failed to fetch departments: %v", err)
+		return err
 	}
-	fmt.Fprintf(os.Stderr, "Found %d departments\n", len(departments))
-
-	fmt.Fprintln(os.Stderr, "Fetching jobs...")
-	jobs, err := fetchAllJobs(apiKey, departments)
+	if !strings.EqualFold(jobStatus, "all") && !containsFold(ashbyJobStatuses, jobStatus) {
+		return fmt.Errorf("invalid --job-status %q (must be %s, or all)", jobStatus, strings.Join(ashbyJobStatuses, ", "))
+	}
+	for _, status := range statusFilter {
+		if !containsFold(ashbyApplicationStatuses, status) {
+			return fmt.Errorf("invalid --status %q (must be one of %s)", status, strings.Join(ashbyApplicationStatuses, ", "))
+		}
+	}
+	weeks, err := resolveWeeks(since, until, numWeeks)
 	if err != nil {
-		log.Fatalf("failed to fetch jobs: %v", err)
+		return err
 	}
-	fmt.Fprintf(os.Stderr, "Found %d jobs\n", len(jobs))
 
-	fmt.Fprintln(os.Stderr, "Fetching applications...")
-	applications, err := fetchAllApplications(apiKey)
-	if err != nil {
-		log.Fatalf("failed to fetch applications: %v", err)
+	// The histogram covers --histo-weeks (6 months by default) unless a
+	// window was chosen explicitly with --weeks, --since, or --until
+	histoWeeks := getLastNWeeks(histoWeekCount)
+	if includeCurrent {
+		histoWeeks = getLastNWeeksIncludingCurrent(histoWeekCount)
+	}
+	if !cmd.Flags().Changed("histo-weeks") && (cmd.Flags().Changed("weeks") || since != "" || until != "") {
+		histoWeeks = weeks
+		if includeCurrent {
+			histoWeeks = append(weeks[:len(weeks):len(weeks)], getCurrentWeekStart())
+		}
+	}
+
+	// Only fetch applications from the start of the displayed window. Weeks
+	// after the window are simply never looked up when printing.
+	firstWeek := weeks[0]
+	if outputHisto || outputHistoByJob {
+		firstWeek = histoWeeks[0]
 	}
-	fmt.Fprintf(os.Stderr, "Found %d applications\n\n", len(applications))
+	createdAfter, _ := parseWeekStart(firstWeek)
 
 	// Group by job and week
-	// map[jobID]ashbyJobMetrics
+	// map[key]ashbyJobMetrics, keyed by job ID within a single instance. With
+	// several instances, jobs are keyed per instance when broken out, or by
+	// department and title when merged, so job IDs never collide.
 	metrics := make(map[string]*ashbyJobMetrics)
 
-	for _, app := range applications {
-		jobID := app.Job.ID
-		jobInfo, ok := jobs[jobID]
-		if !ok {
-			jobInfo = ashbyJobInfo{Title: app.Job.Title, Department: "No Department"}
-			if jobInfo.Title == "" {
-				jobInfo.Title = "Unknown Job"
-			}
+	// Applications whose job is missing from job.list stay keyed by their own
+	// job ID so that different unknown jobs are not merged together.
+	// Their status is unknown, so they are kept whatever --job-status says.
+	unknownApps := 0
+	unknownJobs := make(map[string]struct{})
+	filteredApps := 0
+	skippedStatus := 0
+
+	// Department names seen across all instances, for --department
+	allDepartments := map[string]struct{}{"No Department": {}}
+
+	for _, inst := range instances {
+		if len(instances) > 1 {
+			progressf("Ashby instance %s:\n", inst.Label)
 		}
 
-		weekStart := getWeekStart(app.CreatedAt)
+		// Department and job maps are per instance to avoid ID collisions
+		departments, jobs, applications, err := fetchAshbyData(cmd.Context(), inst.APIKey, createdAfter)
+		if err != nil {
+			return err
+		}
+		progressf("\n")
+		for _, name := range departments {
+			allDepartments[name] = struct{}{}
+		}
 
-		if _, ok := metrics[jobID]; !ok {
-			metrics[jobID] = &ashbyJobMetrics{
-				Department: jobInfo.Department,
-				Title:      jobInfo.Title,
-				WeekCounts: make(map[string]int),
+		if outputRaw {
+			continue
+		}
+
+		for _, app := range applications {
+			if len(statusFilter) > 0 && !containsFold(statusFilter, app.Status) {
+				skippedStatus++
+				continue
+			}
+			jobID := app.Job.ID
+			jobInfo, ok := jobs[jobID]
+			if ok && !strings.EqualFold(jobStatus, "all") && !strings.EqualFold(jobInfo.Status, jobStatus) {
+				filteredApps++
+				continue
+			}
+			if !ok {
+				unknownApps++
+				unknownJobs[inst.Label+"/"+jobID] = struct{}{}
+				jobInfo = ashbyJobInfo{Title: app.Job.Title, Department: "No Department"}
+				if jobInfo.Title == "" {
+					jobInfo.Title = "Unknown Job"
+					if jobID != "" {
+						jobInfo.Title = fmt.Sprintf("Unknown Job (%s)", jobID)
+					}
+				}
+			}
+
+			key, instance := jobID, ""
+			if len(instances) > 1 {
+				if byInstance {
+					key, instance = inst.Label+"/"+jobID, inst.Label
+				} else {
+					key = jobInfo.Department + "\x00" + jobInfo.Title
+				}
+			}
+
+			// Sources are split out per job here so that --department can
+			// still filter by job before the jobs are merged away
+			var source, sourceType string
+			if bySource {
+				source, sourceType = app.sourceLabels()
+				key += "\x00" + sourceType + "\x00" + source
+			}
+
+			weekStart := getWeekStart(app.CreatedAt)
+
+			if _, ok := metrics[key]; !ok {
+				metrics[key] = &ashbyJobMetrics{
+					Instance:   instance,
+					Department: jobInfo.Department,
+					Title:      jobInfo.Title,
+					Source:     source,
+					SourceType: sourceType,
+					WeekCounts: make(map[string]int),
+				}
 			}
+			metrics[key].WeekCounts[weekStart]++
+		}
+	}
+
+	if outputRaw {
+		return nil
+	}
+
+	if skippedStatus > 0 {
+		progressf("Skipped %d applications whose status is not %s\n\n", skippedStatus, strings.Join(statusFilter, " or "))
+	}
+	if filteredApps > 0 {
+		progressf("Skipped %d applications for jobs whose status is not %s\n\n", filteredApps, jobStatus)
+	}
+
+	if len(departmentFilter) > 0 {
+		if err := filterDepartments(metrics, departmentFilter, allDepartments); err != nil {
+			return err
 		}
-		metrics[jobID].WeekCounts[weekStart]++
 	}
 
+	if warnUnknown && unknownApps > 0 {
+		stderrf("Warning: %d applications referenced %d jobs missing from job.list\n", unknownApps, len(unknownJobs))
+	}
+
+	command, rowHeader := "ashby applicants-by-week", "Job"
+	if bySource {
+		metrics = mergeBySource(metrics)
+		command, rowHeader = "ashby applicants-by-week --by-source", "Source"
+	}
+
+	if wantTemplateData() {
+		if err := printTemplateGrouped(command, metrics, weeks); err != nil || outputFormat == "template" {
+			return err
+		}
+	}
 	if outputHisto {
-		printHistogram(metrics)
+		printHistogram(metrics, histoWeeks, "Applicants", "applicants", histoHeight)
+	} else if outputHistoByJob {
+		printHistogramByJob(metrics, histoWeeks)
 	} else if outputJSON {
-		printJSONGrouped(metrics)
+		if err := printJSONGrouped(metrics, weeks, bySource); err != nil {
+			return err
+		}
+	} else if outputCSV {
+		printCSVGrouped(metrics, weeks, bySource)
 	} else {
-		printTableGrouped(metrics, len(applications))
+		printTableGrouped(metrics, weeks, rowHeader, showAverage)
 	}
+	return nil
 }
 
-func printJSONGrouped(metrics map[string]*ashbyJobMetrics) {
-	type WeekData struct {
-		WeekEnding string `json:"week_ending"`
-		Count      int    `json:"count"`
-	}
-	type JobData struct {
-		Department  string   `json:"department"`
-		Job         string   `json:"job"`
-		Weeks       []WeekData `json:"weeks"`
-		CurrentWeek WeekData `json:"current_week"`
-		Total       int      `json:"total"`
+// mergeBySource combines per-job source metrics into one entry per source
+// (and instance, when broken out). The merged entries carry the source type
+// as Department and the source as Title, so the grouped print functions
+// section them by source type.
+func mergeBySource(metrics map[string]*ashbyJobMetrics) map[string]*ashbyJobMetrics {
+	merged := make(map[string]*ashbyJobMetrics)
+	for _, m := range metrics {
+		key := m.Instance + "\x00" + m.SourceType + "\x00" + m.Source
+		if _, ok := merged[key]; !ok {
+			merged[key] = &ashbyJobMetrics{
+				Instance:   m.Instance,
+				Department: m.SourceType,
+				Title:      m.Source,
+				Source:     m.Source,
+				SourceType: m.SourceType,
+				WeekCounts: make(map[string]int),
+			}
+		}
+		for week, count := range m.WeekCounts {
+			merged[key].WeekCounts[week] += count
+		}
 	}
+	return merged
+}
 
-	allWeeks := getLast4Weeks()
+// printJSONGrouped outputs one entry per job, or per source when bySource is
+// set and metrics come from mergeBySource.
+func printJSONGrouped(metrics map[string]*ashbyJobMetrics, allWeeks []string, bySource bool) error {
 	currentWeek := getCurrentWeekStart()
-	var output []JobData
+	var output []AshbyJobJSON
 
 	for _, m := range metrics {
-		var weeks []WeekData
+		var weeks []WeekCountJSON
 		total := 0
 		// Include all weeks, even those with zero count
 		for _, week := range allWeeks {
 			count := m.WeekCounts[week]
-			weeks = append(weeks, WeekData{WeekEnding: weekStartToEnd(week), Count: count})
+			weeks = append(weeks, WeekCountJSON{WeekEnding: weekStartToEnd(week), Count: count})
 			total += count
 		}
-		output = append(output, JobData{
-			Department: m.Department,
-			Job: m.Title,
-			Weeks: weeks,
-			CurrentWeek: WeekData{WeekEnding: weekStartToEnd(currentWeek), Count: m.WeekCounts[currentWeek]},
-			Total: total,
-		})
+		data := AshbyJobJSON{
+			Instance:    m.Instance,
+			Weeks:       weeks,
+			CurrentWeek: WeekCountJSON{WeekEnding: weekStartToEnd(currentWeek), Count: m.WeekCounts[currentWeek]},
+			Total:       total,
+			Average:     weeklyAverage(total, len(allWeeks)),
+		}
+		if bySource {
+			data.SourceType, data.Source = m.SourceType, m.Source
+		} else {
+			data.Department, data.Job = m.Department, m.Title
+		}
+		output = append(output, data)
 	}
 
 	sort.Slice(output, func(i, j int) bool {
+		if output[i].Instance != output[j].Instance {
+			return output[i].Instance < output[j].Instance
+		}
 		if output[i].Department != output[j].Department {
 			return output[i].Department < output[j].Department
 		}
-		return output[i].Job < output[j].Job
+		if output[i].SourceType != output[j].SourceType {
+			return output[i].SourceType < output[j].SourceType
+		}
+		if output[i].Job != output[j].Job {
+			return output[i].Job < output[j].Job
+		}
+		return output[i].Source < output[j].Source
+	})
+
+	return printJSON(output)
+}
+
+// printCSVGrouped writes one CSV or TSV row per job (see --output) to stdout:
+// department, job, a count for each week (headed by its week-ending date), the
+// current week, and the total over the completed weeks. With bySource the
+// first two columns are the source type and source instead.
+func printCSVGrouped(metrics map[string]*ashbyJobMetrics, weeks []string, bySource bool) {
+	var jobs []*ashbyJobMetrics
+	for _, m := range metrics {
+		jobs = append(jobs, m)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		if jobs[i].group() != jobs[j].group() {
+			return jobs[i].group() < jobs[j].group()
+		}
+		return jobs[i].Title < jobs[j].Title
+	})
+
+	currentWeek := getCurrentWeekStart()
+
+	header := []string{"department", "job"}
+	if bySource {
+		header = []string{"source_type", "source"}
+	}
+	for _, week := range weeks {
+		header = append(header, weekStartToEnd(week))
+	}
+	header = append(header, "current", "total")
+	printRecord(outputFormat, header)
+
+	for _, job := range jobs {
+		row := []string{job.group(), job.Title}
+		total := 0
+		for _, week := range weeks {
+			row = append(row, strconv.Itoa(job.WeekCounts[week]))
+			total += job.WeekCounts[week]
+		}
+		row = append(row, strconv.Itoa(job.WeekCounts[currentWeek]), strconv.Itoa(total))
+		printRecord(outputFormat, row)
+	}
+}
+
+// printTemplateGrouped outputs per-job metrics as templateData, grouped by
+// department.
+func printTemplateGrouped(command string, metrics map[string]*ashbyJobMetrics, weeks []string) error {
+	var jobs []*ashbyJobMetrics
+	for _, m := range metrics {
+		jobs = append(jobs, m)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		if jobs[i].group() != jobs[j].group() {
+			return jobs[i].group() < jobs[j].group()
+		}
+		return jobs[i].Title < jobs[j].Title
 	})
 
-	b, _ := json.MarshalIndent(output, "", "  ")
-	fmt.Println(string(b))
+	data := newTemplateData(command, "", weeks, getCurrentWeekStart())
+	for _, job := range jobs {
+		data.addRow(job.Title, job.group(), job.WeekCounts)
+	}
+	return data.output()
+}
+
+const (
+	defaultHistoHeight = 15
+	defaultHistoWeeks  = 26
+
+	// Larger values are clamped: no terminal shows them usefully
+	maxHistoHeight = 100
+	maxHistoWeeks  = 156
+)
+
+// addHistogramFlags adds --histo-height and --histo-weeks to cmd.
+func addHistogramFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("histo-height", defaultHistoHeight, fmt.Sprintf("Histogram height in rows (1-%d)", maxHistoHeight))
+	cmd.Flags().Int("histo-weeks", defaultHistoWeeks, fmt.Sprintf("Completed weeks shown in histograms, one column each (1-%d)", maxHistoWeeks))
 }
 
-func printHistogram(metrics map[string]*ashbyJobMetrics) {
-	weeks := getLast26Weeks()
+// histogramFlags returns the --histo-height and --histo-weeks values. Values
+// below 1 are an error; values above the maximum are clamped with a warning.
+func histogramFlags(cmd *cobra.Command) (height, weeks int, err error) {
+	height, _ = cmd.Flags().GetInt("histo-height")
+	weeks, _ = cmd.Flags().GetInt("histo-weeks")
+	if height < 1 {
+		return 0, 0, fmt.Errorf("--histo-height must be positive, got %d", height)
+	}
+	if weeks < 1 {
+		return 0, 0, fmt.Errorf("--histo-weeks must be positive, got %d", weeks)
+	}
+	if height > maxHistoHeight {
+		stderrf("Warning: --histo-height %d is too tall; using %d\n", height, maxHistoHeight)
+		height = maxHistoHeight
+	}
+	if weeks > maxHistoWeeks {
+		stderrf("Warning: --histo-weeks %d is too wide; using %d\n", weeks, maxHistoWeeks)
+		weeks = maxHistoWeeks
+	}
+	return height, weeks, nil
+}
 
+// printHistogram charts the weekly totals across all jobs. title names what
+// is counted (e.g. "Applicants") and unit is its lower-case plural for labels.
+// maxBarHeight is the height of the tallest bar in rows.
+func printHistogram(metrics map[string]*ashbyJobMetrics, weeks []string, title, unit string, maxBarHeight int) {
 	// Aggregate counts per week across all jobs
 	weekTotals := make(map[string]int)
 	for _, m := range metrics {
@@ -376,7 +975,7 @@ func printHistogram(metrics map[string]*ashbyJobMetrics) {
 		}
 	}
 
-	// Get counts for last 26 weeks in order
+	// Get counts for each week in order
 	var counts []int
 	maxCount := 0
 	for _, week := range weeks {
@@ -388,86 +987,265 @@ func printHistogram(metrics map[string]*ashbyJobMetrics) {
 	}
 
 	if maxCount == 0 {
-		fmt.Println("No applications in the last 6 months")
+		fmt.Fprintf(stdout, "No %s in the %s\n", unit, strings.ToLower(describeHistogramWeeks(weeks)))
 		return
 	}
 
 	// Print title
-	fmt.Println("Applicants per Week (Last 6 Months)")
-	fmt.Println()
+	fmt.Fprintf(stdout, "%s per Week (%s)\n", title, describeHistogramWeeks(weeks))
+	fmt.Fprintln(stdout)
 
 	// Draw histogram (vertical bars going down)
 	barChar := "█"
-	maxBarHeight := 15
 	labelWidth := 12
 
 	// Print bars row by row from top to bottom
 	for row := maxBarHeight; row >= 1; row-- {
 		threshold := float64(row) / float64(maxBarHeight) * float64(maxCount)
-		fmt.Printf("%*s", labelWidth, "")
+		fmt.Fprintf(stdout, "%*s", labelWidth, "")
 		for _, count := range counts {
 			if float64(count) >= threshold {
-				fmt.Print(barChar)
+				fmt.Fprint(stdout, barChar)
 			} else {
-				fmt.Print(" ")
+				fmt.Fprint(stdout, " ")
 			}
 		}
-		fmt.Println()
+		fmt.Fprintln(stdout)
 	}
 
 	// Print x-axis
-	fmt.Printf("%*s", labelWidth, "")
-	fmt.Println(strings.Repeat("-", 26))
+	fmt.Fprintf(stdout, "%*s", labelWidth, "")
+	fmt.Fprintln(stdout, strings.Repeat("-", len(weeks)))
 
 	// Print month labels
-	fmt.Printf("%*s", labelWidth, "")
-	lastMonth := ""
-	for _, week := range weeks {
-		t, _ := time.Parse("2006-01-02", week)
-		month := t.Format("Jan")
-		if month != lastMonth {
-			fmt.Print(month[:1])
-			lastMonth = month
-		} else {
-			fmt.Print(" ")
-		}
-	}
-	fmt.Println()
+	fmt.Fprintf(stdout, "%*s", labelWidth, "")
+	fmt.Fprintln(stdout, monthAxis(weeks))
 
 	// Print legend with scale
-	fmt.Println()
-	fmt.Printf("Scale: Each row = %.1f applicants\n", float64(maxCount)/float64(maxBarHeight))
-	fmt.Printf("Max: %d applicants/week\n", maxCount)
+	fmt.Fprintln(stdout)
+	fmt.Fprintf(stdout, "Scale: Each row = %.1f %s\n", float64(maxCount)/float64(maxBarHeight), unit)
+	fmt.Fprintf(stdout, "Max: %d %s/week\n", maxCount, unit)
 
 	// Print weekly totals summary
-	fmt.Println()
-	fmt.Println("Weekly Breakdown:")
-	fmt.Println()
+	fmt.Fprintln(stdout)
+	fmt.Fprintln(stdout, "Weekly Breakdown:")
+	fmt.Fprintln(stdout)
 
 	total := 0
+	partial, partialCount := false, 0
 	for i, week := range weeks {
 		count := counts[i]
 		total += count
+		label := formatWeekEnd(week)
+		if isPartialWeek(week) {
+			label += "*"
+			partial = true
+			partialCount = count
+		} else {
+			label += " "
+		}
 		if count > 0 {
 			bar := strings.Repeat("▪", int(float64(count)/float64(maxCount)*30)+1)
-			fmt.Printf("  %s  %3d %s\n", formatWeekEnd(week), count, bar)
+			fmt.Fprintf(stdout, "  %s %3d %s\n", label, count, bar)
 		} else {
-			fmt.Printf("  %s  %3d\n", formatWeekEnd(week), count)
+			fmt.Fprintf(stdout, "  %s %3d\n", label, count)
+		}
+	}
+	if partial {
+		fmt.Fprintln(stdout)
+		fmt.Fprintln(stdout, "  * current week, still in progress")
+	}
+	fmt.Fprintln(stdout)
+	fmt.Fprintf(stdout, "  Total: %d %s over %d weeks\n", total, unit, len(weeks))
+	if partial {
+		// The partial week would drag the average down
+		if complete := len(weeks) - 1; complete > 0 {
+			fmt.Fprintf(stdout, "  Average: %.1f %s/week (completed weeks)\n", float64(total-partialCount)/float64(complete), unit)
 		}
+	} else {
+		fmt.Fprintf(stdout, "  Average: %.1f %s/week\n", float64(total)/float64(len(weeks)), unit)
+	}
+}
+
+// containsFold reports whether list contains s, ignoring case.
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterDepartments removes jobs outside the wanted departments from metrics.
+// Names match case-insensitively. A name matching none of the known
+// departments is an error that lists the available names.
+func filterDepartments(metrics map[string]*ashbyJobMetrics, wanted []string, known map[string]struct{}) error {
+	keep := make(map[string]bool)
+	for _, name := range wanted {
+		found := false
+		for dept := range known {
+			if strings.EqualFold(dept, name) {
+				keep[strings.ToLower(dept)] = true
+				found = true
+			}
+		}
+		if !found && !dryRun {
+			var names []string
+			for dept := range known {
+				names = append(names, dept)
+			}
+			sort.Strings(names)
+			return fmt.Errorf("no department named %q; available departments:\n  %s", name, strings.Join(names, "\n  "))
+		}
+	}
+
+	for key, m := range metrics {
+		if !keep[strings.ToLower(m.Department)] {
+			delete(metrics, key)
+		}
+	}
+	return nil
+}
+
+// printHistogramByJob prints one sparkline per job over weeks, busiest jobs
+// first. Bars are scaled to the busiest week of any job so lines compare.
+func printHistogramByJob(metrics map[string]*ashbyJobMetrics, weeks []string) {
+	type jobLine struct {
+		job   *ashbyJobMetrics
+		total int
+	}
+	var lines []jobLine
+	maxCount := 0
+	for _, m := range metrics {
+		total := 0
+		for _, week := range weeks {
+			count := m.WeekCounts[week]
+			total += count
+			if count > maxCount {
+				maxCount = count
+			}
+		}
+		if total > 0 {
+			lines = append(lines, jobLine{job: m, total: total})
+		}
+	}
+
+	if len(lines) == 0 {
+		fmt.Fprintf(stdout, "No applicants in the %s\n", strings.ToLower(describeHistogramWeeks(weeks)))
+		return
+	}
+
+	sort.Slice(lines, func(i, j int) bool {
+		if lines[i].total != lines[j].total {
+			return lines[i].total > lines[j].total
+		}
+		return lines[i].job.Title < lines[j].job.Title
+	})
+
+	fmt.Fprintf(stdout, "Applicants per Week by Job (%s)\n", describeHistogramWeeks(weeks))
+	fmt.Fprintln(stdout)
+	fmt.Fprintf(stdout, "%-35s %-25s %-*s %6s\n", "Job", "Department", len(weeks), "Weeks", "Total")
+	fmt.Fprintln(stdout, strings.Repeat("-", 35+25+len(weeks)+9))
+
+	for _, line := range lines {
+		var bar strings.Builder
+		for _, week := range weeks {
+			count := line.job.WeekCounts[week]
+			if count == 0 {
+				bar.WriteRune(' ')
+				continue
+			}
+			level := (count*len(sparkBlocks) - 1) / maxCount
+			bar.WriteRune(sparkBlocks[level])
+		}
+		fmt.Fprintf(stdout, "%-35s %-25s %s %6d\n", truncateLabel(line.job.Title, 35), truncateLabel(line.job.group(), 25), bar.String(), line.total)
+	}
+
+	fmt.Fprintln(stdout)
+	fmt.Fprintf(stdout, "Scale: %s = %d applicants/week\n", string(sparkBlocks[len(sparkBlocks)-1]), maxCount)
+}
+
+// monthAxis returns the month labels for a histogram with one column per
+// week. Each month's abbreviation is centered under the first week starting
+// in it, and is skipped when it would touch the previous label. The month the
+// chart starts partway through is labeled at the first column only if that
+// leaves room for the next month. The last label may run past the final
+// column.
+func monthAxis(weeks []string) string {
+	type monthLabel struct {
+		text  string
+		start int
+	}
+	var labels []monthLabel
+	lastMonth := ""
+	for i, week := range weeks {
+		t, _ := time.Parse("2006-01-02", week)
+		month := t.Format("Jan")
+		if month == lastMonth {
+			continue
+		}
+		lastMonth = month
+		start := i - len(month)/2
+		if start < 0 {
+			start = 0
+		}
+		labels = append(labels, monthLabel{month, start})
+	}
+	if len(labels) > 1 && labels[1].start <= len(labels[0].text) {
+		if t, _ := time.Parse("2006-01-02", weeks[0]); t.Day() > 7 {
+			labels = labels[1:]
+		}
+	}
+
+	axis := []rune(strings.Repeat(" ", len(weeks)))
+	free := 0 // first column a label may start at
+	for _, label := range labels {
+		if label.start < free {
+			continue
+		}
+		for len(axis) < label.start+len(label.text) {
+			axis = append(axis, ' ')
+		}
+		copy(axis[label.start:], []rune(label.text))
+		free = label.start + len(label.text) + 1
 	}
-	fmt.Println()
-	fmt.Printf("  Total: %d applicants over 26 weeks\n", total)
-	fmt.Printf("  Average: %.1f applicants/week\n", float64(total)/26.0)
+	return strings.TrimRight(string(axis), " ")
 }
 
-func printTableGrouped(metrics map[string]*ashbyJobMetrics, totalApps int) {
-	weeks := getLast4Weeks()
+// truncateLabel shortens s to at most width runes, ending in "..." when cut.
+func truncateLabel(s string, width int) string {
+	r := []rune(s)
+	if len(r) <= width {
+		return s
+	}
+	return string(r[:width-3]) + "..."
+}
+
+// describeHistogramWeeks titles the histogram, keeping the familiar
+// "Last 6 Months" for the default 26-week window. A trailing partial week is
+// noted rather than counted in the window.
+func describeHistogramWeeks(weeks []string) string {
+	if n := len(weeks); n > 1 && isPartialWeek(weeks[n-1]) {
+		return describeHistogramWeeks(weeks[:n-1]) + " + Current Week"
+	}
+	if len(weeks) == 26 && weeks[len(weeks)-1] == getLastCompletedWeekStart() {
+		return "Last 6 Months"
+	}
+	return describeWeeks(weeks)
+}
+
+// printTableGrouped prints metrics sectioned by group with subtotals.
+// rowHeader heads the label column ("Job", or "Source" with --by-source).
+// With showAverage an Average row after Total gives the mean weekly total in
+// the Total column.
+func printTableGrouped(metrics map[string]*ashbyJobMetrics, weeks []string, rowHeader string, showAverage bool) {
 	currentWeek := getCurrentWeekStart()
 
 	// Group jobs by department
 	deptJobs := make(map[string][]*ashbyJobMetrics)
 	for _, m := range metrics {
-		deptJobs[m.Department] = append(deptJobs[m.Department], m)
+		deptJobs[m.group()] = append(deptJobs[m.group()], m)
 	}
 
 	// Sort departments
@@ -484,10 +1262,8 @@ func printTableGrouped(metrics map[string]*ashbyJobMetrics, totalApps int) {
 		})
 	}
 
-	// Create table
-	table := newWeeklyTable(35, 10, weeks)
-	table.printHeader("Job", currentWeek)
-	table.printSeparator(currentWeek)
+	// Create table, sized to the longest job title
+	table := newAutoWeeklyTable(weeks, currentWeek)
 
 	// Print each department and its jobs
 	weekTotals := make(map[string]int)
@@ -496,18 +1272,12 @@ func printTableGrouped(metrics map[string]*ashbyJobMetrics, totalApps int) {
 		jobs := deptJobs[dept]
 
 		// Print department header
-		fmt.Printf("\n%s\n", dept)
+		table.addSection(dept)
 
 		deptWeekTotals := make(map[string]int)
 		for _, job := range jobs {
-			// Truncate job title if too long
-			displayTitle := "  " + job.Title
-			if len(displayTitle) > table.labelColWidth-2 {
-				displayTitle = displayTitle[:table.labelColWidth-5] + "..."
-			}
-
-			// Print job row and accumulate totals
-			table.printRow(displayTitle, job.WeekCounts, currentWeek)
+			// Add job row and accumulate totals
+			table.addRow("  "+job.Title, job.WeekCounts)
 
 			// Update totals
 			for _, week := range weeks {
@@ -521,10 +1291,29 @@ func printTableGrouped(metrics map[string]*ashbyJobMetrics, totalApps int) {
 		}
 
 		// Print department subtotal
-		table.printRow("  Subtotal", deptWeekTotals, currentWeek)
+		table.addRow("  Subtotal", deptWeekTotals)
 	}
 
 	// Print totals
-	table.printSeparator(currentWeek)
-	table.printTotalsRow("Total", weekTotals, currentWeek)
+	table.addSeparator()
+	table.addTotalsRow("Total", weekTotals)
+	if showAverage {
+		grandTotal := 0
+		for _, week := range weeks {
+			grandTotal += weekTotals[week]
+		}
+		// Only the Total column is filled: weeks and Current stay blank
+		cells := make([]string, len(weeks)+2)
+		cells[len(cells)-1] = fmt.Sprintf("%.1f", weeklyAverage(grandTotal, len(weeks)))
+		table.addTextRow("Average", cells)
+	}
+	table.flush(rowHeader)
+}
+
+// weeklyAverage returns total spread over n weeks, or 0 when there are none.
+func weeklyAverage(total, n int) float64 {
+	if n == 0 {
+		return 0
+	}
+	return float64(total) / float64(n)
 }
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+var (
+	// ashbyCacheDir is where cached Ashby responses are stored.
+	ashbyCacheDir string
+
+	// ashbyMaxCacheAge enables the response cache when non-zero: responses
+	// younger than this are served from disk instead of the API.
+	ashbyMaxCacheAge time.Duration
+
+	// ashbyNoCache forces a fresh fetch, still refreshing the cache.
+	ashbyNoCache bool
+)
+
+// ashbyCachedEndpoints are the list endpoints whose responses may be cached.
+var ashbyCachedEndpoints = map[string]bool{
+	"application.list": true,
+	"job.list":         true,
+	"department.list":  true,
+}
+
+func init() {
+	ashbyCmd.PersistentFlags().StringVar(&ashbyCacheDir, "cache-dir", "", "Directory for cached Ashby responses (default: <user cache dir>/scorecard/ashby)")
+	ashbyCmd.PersistentFlags().DurationVar(&ashbyMaxCacheAge, "max-cache-age", 0, "Reuse cached Ashby responses younger than this, e.g. 1h (0 = no caching)")
+	ashbyCmd.PersistentFlags().BoolVar(&ashbyNoCache, "no-cache", false, "Ignore cached Ashby responses and fetch fresh data")
+}
+
+// ashbyCachePath returns the cache file for one request, keyed by endpoint and
+// a hash of the API base, API key, and request body so that different
+// accounts, filters, and pages never share an entry. It returns "" when the
+// request is not cacheable or caching is off.
+func ashbyCachePath(apiKey, endpoint string, body []byte) string {
+	if ashbyMaxCacheAge <= 0 || !ashbyCachedEndpoints[endpoint] {
+		return ""
+	}
+	dir := ashbyCacheDir
+	if dir == "" {
+		base, err := os.UserCacheDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(base, "scorecard", "ashby")
+	}
+	sum := sha256.Sum256(append([]byte(ashbyAPIBase+"\x00"+apiKey+"\x00"), body...))
+	return filepath.Join(dir, endpoint+"-"+hex.EncodeToString(sum[:12])+".json")
+}
+
+// readAshbyCache returns the cached response at path if it is younger than
+// --max-cache-age and --no-cache is not set.
+func readAshbyCache(path string) ([]byte, bool) {
+	if ashbyNoCache {
+		return nil, false
+	}
+	return readCacheFile(path, ashbyMaxCacheAge)
+}
+
+// writeAshbyCache saves a response at path.
+func writeAshbyCache(path string, data []byte) {
+	writeCacheFile(path, data)
+}
@@ -0,0 +1,298 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// ashbyFunnelStages are the funnel stages in order. Each application counts
+// toward every stage up to the one it is in now.
+var ashbyFunnelStages = []string{"Applied", "Screen", "Onsite", "Offer"}
+
+// funnelStage returns the index in ashbyFunnelStages of the furthest stage
+// the application reached, judged by its current interview stage type.
+// Archived applications count as applied only: Ashby does not report the
+// stage they were archived from.
+func (a *ashbyApplication) funnelStage() int {
+	if a.Status == "Hired" {
+		return 3
+	}
+	if a.CurrentInterviewStage == nil {
+		return 0
+	}
+	switch a.CurrentInterviewStage.Type {
+	case "PreInterviewScreen":
+		return 1
+	case "Active":
+		return 2
+	case "Offer", "Hired":
+		return 3
+	}
+	return 0
+}
+
+// ashbyFunnel counts applications reaching each of ashbyFunnelStages.
+type ashbyFunnel struct {
+	Department string
+	Title      string
+	Counts     [4]int
+}
+
+func (f *ashbyFunnel) add(app *ashbyApplication) {
+	for i := 0; i <= app.funnelStage(); i++ {
+		f.Counts[i]++
+	}
+}
+
+// conversion returns the percentage of stage i-1 that reached stage i, or
+// false for the first stage or when stage i-1 is empty.
+func (f *ashbyFunnel) conversion(i int) (float64, bool) {
+	if i == 0 || f.Counts[i-1] == 0 {
+		return 0, false
+	}
+	return float64(f.Counts[i]) / float64(f.Counts[i-1]) * 100, true
+}
+
+// ofApplied returns the percentage of applications that reached stage i, or
+// false when there are none.
+func (f *ashbyFunnel) ofApplied(i int) (float64, bool) {
+	if f.Counts[0] == 0 {
+		return 0, false
+	}
+	return float64(f.Counts[i]) / float64(f.Counts[0]) * 100, true
+}
+
+func init() {
+	ashbyCmd.AddCommand(funnelCmd)
+	funnelCmd.Flags().Bool("json", false, "Output in JSON format")
+	funnelCmd.Flags().Bool("by-job", false, "Show the funnel for each job")
+	funnelCmd.Flags().Int("weeks", 12, "Count applications created in the last N completed weeks (1-52)")
+	funnelCmd.Flags().String("since", "", "First week of applications to count (YYYY-MM-DD, now-4w, last-week, ...)")
+	funnelCmd.Flags().String("until", "", "Last week of applications to count (YYYY-MM-DD, now, last-week, ...)")
+}
+
+var funnelCmd = &cobra.Command{
+	Use:   "funnel",
+	Short: "Show how many applications reached screen, onsite, and offer",
+	Long: `Fetches applications created in the selected weeks and counts how many reached
+each interview stage: Applied, Screen, Onsite, and Offer, with the conversion
+from each stage to the next.
+
+Ashby only reports the stage an application is in now, so an application counts
+toward every stage up to its current one, judged by the stage type (pre-interview
+screen, active interviews, offer). Hired applications count as offers. Archived
+applications count as applied only, since the stage they left from is not
+reported; recent weeks also undercount later stages while candidates are still
+in process.`,
+	RunE: runFunnel,
+}
+
+func runFunnel(cmd *cobra.Command, args []string) error {
+	apiKey, err := loadAshbyEnv("ASHBY_API_KEY")
+	if err != nil {
+		return err
+	}
+	byJob, _ := cmd.Flags().GetBool("by-job")
+	since, _ := cmd.Flags().GetString("since")
+	until, _ := cmd.Flags().GetString("until")
+	numWeeks := weeksFlag(cmd)
+
+	if numWeeks < 1 || numWeeks > 52 {
+		return fmt.Errorf("--weeks must be between 1 and 52, got %d", numWeeks)
+	}
+	if outputFormat == "template" {
+		return fmt.Errorf("ashby funnel does not support --output template")
+	}
+	weeks, err := resolveWeeks(since, until, numWeeks)
+	if err != nil {
+		return err
+	}
+	inWindow := make(map[string]bool)
+	for _, week := range weeks {
+		inWindow[week] = true
+	}
+	createdAfter, _ := parseWeekStart(weeks[0])
+
+	_, jobs, applications, err := fetchAshbyData(cmd.Context(), apiKey, createdAfter)
+	if err != nil {
+		return err
+	}
+	progressf("\n")
+
+	total := &ashbyFunnel{Title: "All jobs"}
+	byJobID := make(map[string]*ashbyFunnel)
+	archived := 0
+	for i := range applications {
+		app := &applications[i]
+		if !inWindow[getWeekStart(app.CreatedAt)] {
+			continue
+		}
+		if app.Status == "Archived" {
+			archived++
+		}
+		total.add(app)
+
+		f, ok := byJobID[app.Job.ID]
+		if !ok {
+			f = &ashbyFunnel{Department: "No Department", Title: app.Job.Title}
+			if job, ok := jobs[app.Job.ID]; ok {
+				f.Department, f.Title = job.Department, job.Title
+			}
+			if f.Title == "" {
+				f.Title = "Unknown Job"
+			}
+			byJobID[app.Job.ID] = f
+		}
+		f.add(app)
+	}
+
+	var funnels []*ashbyFunnel
+	for _, f := range byJobID {
+		funnels = append(funnels, f)
+	}
+	sort.Slice(funnels, func(i, j int) bool {
+		if funnels[i].Counts[0] != funnels[j].Counts[0] {
+			return funnels[i].Counts[0] > funnels[j].Counts[0]
+		}
+		return funnels[i].Title < funnels[j].Title
+	})
+
+	if jsonOutput() {
+		return printFunnelJSON(total, funnels, weeks, archived, byJob)
+	}
+
+	window := describeWeeks(weeks)
+	if byJob {
+		printFunnelByJob(total, funnels, window)
+	} else {
+		printFunnel(total, window)
+	}
+	if archived > 0 {
+		printTableNote("\n%d archived applications count as applied only; Ashby does not report the stage they left from.\n", archived)
+	}
+	return nil
+}
+
+// formatFunnelPercent formats a percentage from ashbyFunnel, or "-" if none.
+func formatFunnelPercent(pct float64, ok bool) string {
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", pct)
+}
+
+// printFunnel prints one row per stage with its count, the conversion from
+// the previous stage, and the share of all applications.
+func printFunnel(f *ashbyFunnel, window string) {
+	headers := []string{"Stage", "Applications", "Conversion", "Of Applied"}
+	var rows [][]string
+	for i, stage := range ashbyFunnelStages {
+		rows = append(rows, []string{
+			stage,
+			strconv.Itoa(f.Counts[i]),
+			formatFunnelPercent(f.conversion(i)),
+			formatFunnelPercent(f.ofApplied(i)),
+		})
+	}
+
+	printTableTitle("Application Funnel (%s)", window)
+	if gridFormat() {
+		printGrid(headers, rows)
+		return
+	}
+	printRow := func(row []string) {
+		fmt.Fprintf(stdout, "%-12s", row[0])
+		for _, cell := range row[1:] {
+			fmt.Fprintf(stdout, " %13s", cell)
+		}
+		fmt.Fprintln(stdout)
+	}
+	printRow(headers)
+	fmt.Fprintln(stdout, strings.Repeat("-", 12+14*(len(headers)-1)))
+	for _, row := range rows {
+		printRow(row)
+	}
+}
+
+// printFunnelByJob prints one row per job with the count at each stage and,
+// after the first, the conversion from the previous stage in parentheses.
+func printFunnelByJob(total *ashbyFunnel, funnels []*ashbyFunnel, window string) {
+	headers := append([]string{"Job", "Department"}, ashbyFunnelStages...)
+	row := func(f *ashbyFunnel) []string {
+		cells := []string{f.Title, f.Department}
+		for i := range ashbyFunnelStages {
+			cell := strconv.Itoa(f.Counts[i])
+			if pct, ok := f.conversion(i); ok {
+				cell += fmt.Sprintf(" (%.0f%%)", pct)
+			}
+			cells = append(cells, cell)
+		}
+		return cells
+	}
+	var rows [][]string
+	for _, f := range funnels {
+		rows = append(rows, row(f))
+	}
+	footer := row(total)
+	footer[0], footer[1] = "Total", ""
+
+	printTableTitle("Application Funnel by Job (%s)", window)
+	if gridFormat() {
+		printGrid(headers, append(rows, footer))
+		return
+	}
+	printRow := func(row []string) {
+		fmt.Fprintf(stdout, "%-35s %-25s", truncateLabel(row[0], 35), truncateLabel(row[1], 25))
+		for _, cell := range row[2:] {
+			fmt.Fprintf(stdout, " %11s", cell)
+		}
+		fmt.Fprintln(stdout)
+	}
+	width := 61 + 12*len(ashbyFunnelStages)
+	printRow(headers)
+	fmt.Fprintln(stdout, strings.Repeat("-", width))
+	for _, r := range rows {
+		printRow(r)
+	}
+	fmt.Fprintln(stdout, strings.Repeat("-", width))
+	printRow(footer)
+}
+
+func printFunnelJSON(total *ashbyFunnel, funnels []*ashbyFunnel, weeks []string, archived int, byJob bool) error {
+	percent := func(pct float64, ok bool) *float64 {
+		if !ok {
+			return nil
+		}
+		return &pct
+	}
+	stages := func(f *ashbyFunnel) []FunnelStageJSON {
+		var data []FunnelStageJSON
+		for i, stage := range ashbyFunnelStages {
+			data = append(data, FunnelStageJSON{
+				Stage:         stage,
+				Count:         f.Counts[i],
+				ConversionPct: percent(f.conversion(i)),
+				OfAppliedPct:  percent(f.ofApplied(i)),
+			})
+		}
+		return data
+	}
+
+	output := FunnelJSON{
+		From:     weeks[0],
+		To:       weekStartToEnd(weeks[len(weeks)-1]),
+		Stages:   stages(total),
+		Archived: archived,
+	}
+	if !byJob {
+		return printJSONList(output.Stages, output)
+	}
+	for _, f := range funnels {
+		output.Jobs = append(output.Jobs, FunnelJobJSON{Department: f.Department, Job: f.Title, Stages: stages(f)})
+	}
+	return printJSONList(output.Jobs, output)
+}
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+type ashbyOffer struct {
+	ID               string     `json:"id"`
+	ApplicationID    string     `json:"applicationId"`
+	AcceptanceStatus string     `json:"acceptanceStatus"`
+	OfferStatus      string     `json:"offerStatus"`
+	DecidedAt        *time.Time `json:"decidedAt"`
+	LatestVersion    struct {
+		ID        string    `json:"id"`
+		CreatedAt time.Time `json:"createdAt"`
+	} `json:"latestVersion"`
+}
+
+type ashbyOfferListResponse struct {
+	ashbyStatus
+	Results           []ashbyOffer `json:"results"`
+	MoreDataAvailable bool         `json:"moreDataAvailable"`
+	NextCursor        string       `json:"nextCursor"`
+}
+
+type weeklyOfferCounts struct {
+	Extended int
+	Accepted int
+}
+
+// acceptanceRate returns the percentage of extended offers that were accepted,
+// or false if no offers were extended.
+func (c weeklyOfferCounts) acceptanceRate() (float64, bool) {
+	if c.Extended == 0 {
+		return 0, false
+	}
+	return float64(c.Accepted) / float64(c.Extended) * 100, true
+}
+
+func init() {
+	ashbyCmd.AddCommand(offerAcceptanceCmd)
+	offerAcceptanceCmd.Flags().Bool("json", false, "Output in JSON format")
+}
+
+var offerAcceptanceCmd = &cobra.Command{
+	Use:   "offer-acceptance",
+	Short: "Show offers extended vs accepted by week",
+	Long: `Fetches all offers and groups them by the week the latest offer version was
+created, reporting how many were extended and how many of those were accepted,
+along with the acceptance rate.`,
+	RunE: runOfferAcceptance,
+}
+
+func fetchAllOffers(ctx context.Context, apiKey string) ([]ashbyOffer, error) {
+	var offers []ashbyOffer
+	var cursor string
+	var pager ashbyPager
+	progress := newFetchProgress("offers")
+	defer progress.done()
+
+	for {
+		body := map[string]interface{}{"limit": 100}
+		if cursor != "" {
+			body["cursor"] = cursor
+		}
+
+		respBody, err := ashbyRequest(ctx, apiKey, "offer.list", body)
+		if err != nil {
+			return nil, err
+		}
+
+		var response ashbyOfferListResponse
+		if err := json.Unmarshal(respBody, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		if err := response.err(); err != nil {
+			return nil, err
+		}
+
+		offers = append(offers, response.Results...)
+		progress.page(len(response.Results))
+
+		if !response.MoreDataAvailable {
+			break
+		}
+		if err := pager.next(response.NextCursor); err != nil {
+			return nil, err
+		}
+		cursor = response.NextCursor
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	return offers, nil
+}
+
+func runOfferAcceptance(cmd *cobra.Command, args []string) error {
+	apiKey, err := loadAshbyEnv("ASHBY_API_KEY")
+	if err != nil {
+		return err
+	}
+	outputJSON := jsonOutput()
+
+	progressf("Fetching offers...\n")
+	offers, err := fetchAllOffers(cmd.Context(), apiKey)
+	if err != nil {
+		return fmt.Errorf("failed to fetch offers: %w", err)
+	}
+	progressf("Found %d offers\n\n", len(offers))
+
+	weeks := getLastNWeeks(defaultWeekCount())
+	currentWeek := getCurrentWeekStart()
+
+	// Offers are bucketed by the week they were extended; acceptance is
+	// attributed to that same week so the rate reflects each week's cohort.
+	counts := make(map[string]*weeklyOfferCounts)
+	for _, week := range weeks {
+		counts[week] = &weeklyOfferCounts{}
+	}
+	counts[currentWeek] = &weeklyOfferCounts{}
+
+	for _, offer := range offers {
+		c, ok := counts[getWeekStart(offer.LatestVersion.CreatedAt)]
+		if !ok {
+			continue
+		}
+		c.Extended++
+		if offer.AcceptanceStatus == "Accepted" {
+			c.Accepted++
+		}
+	}
+
+	var totals weeklyOfferCounts
+	for _, week := range weeks {
+		totals.Extended += counts[week].Extended
+		totals.Accepted += counts[week].Accepted
+	}
+
+	if wantTemplateData() {
+		extended := make(map[string]int)
+		accepted := make(map[string]int)
+		for week, c := range counts {
+			extended[week] = c.Extended
+			accepted[week] = c.Accepted
+		}
+		data := newTemplateData("ashby offer-acceptance", "", weeks, currentWeek)
+		data.addRow("Extended", "", extended)
+		data.addRow("Accepted", "", accepted)
+		if err := data.output(); err != nil || outputFormat == "template" {
+			return err
+		}
+	}
+
+	if outputJSON {
+		if err := printOfferAcceptanceJSON(weeks, counts, currentWeek, totals); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := newWeeklyTable(20, 10, weeks)
+	table.printHeader("Offers", currentWeek)
+	table.printSeparator(currentWeek)
+
+	extendedCounts := make([]int, len(weeks))
+	acceptedCounts := make([]int, len(weeks))
+	rates := make([]string, 0, len(weeks)+2)
+	for i, week := range weeks {
+		extendedCounts[i] = counts[week].Extended
+		acceptedCounts[i] = counts[week].Accepted
+		rates = append(rates, formatPercent(counts[week].acceptanceRate()))
+	}
+	rates = append(rates, formatPercent(counts[currentWeek].acceptanceRate()))
+	rates = append(rates, formatPercent(totals.acceptanceRate()))
+
+	table.printRowWithSlice("Extended", extendedCounts, counts[currentWeek].Extended)
+	table.printRowWithSlice("Accepted", acceptedCounts, counts[currentWeek].Accepted)
+	table.printSeparator(currentWeek)
+	table.printTextRow("Acceptance Rate", rates)
+	return nil
+}
+
+func printOfferAcceptanceJSON(weeks []string, counts map[string]*weeklyOfferCounts, currentWeek string, totals weeklyOfferCounts) error {
+	toWeekData := func(weekEnding string, c weeklyOfferCounts) OfferAcceptanceWeekJSON {
+		data := OfferAcceptanceWeekJSON{WeekEnding: weekEnding, Extended: c.Extended, Accepted: c.Accepted}
+		if rate, ok := c.acceptanceRate(); ok {
+			data.AcceptanceRate = &rate
+		}
+		return data
+	}
+
+	var output OfferAcceptanceJSON
+	for _, week := range weeks {
+		output.Weeks = append(output.Weeks, toWeekData(weekStartToEnd(week), *counts[week]))
+	}
+	output.CurrentWeek = toWeekData(weekStartToEnd(currentWeek), *counts[currentWeek])
+	output.Totals = toWeekData("", totals)
+
+	return printJSON(output)
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var offersByWeekCmd = &cobra.Command{
+	Use:   "offers-by-week",
+	Short: "Show offers extended by week for each job",
+	Long: `Fetches all offers and groups them by job and by the week the latest offer
+version was created.
+
+Offers reference an application rather than a job, so applications are fetched
+too in order to find each offer's job. Offers whose application or job cannot
+be found are grouped under "Unknown Job".`,
+	RunE: runOffersByWeek,
+}
+
+func init() {
+	ashbyCmd.AddCommand(offersByWeekCmd)
+	offersByWeekCmd.Flags().Bool("json", false, "Output in JSON format")
+	offersByWeekCmd.Flags().Bool("histo", false, "Display a histogram of weekly totals (last 6 months; see --histo-weeks)")
+	offersByWeekCmd.Flags().Bool("include-current", false, "Add the current partial week to the end of the histogram")
+	addHistogramFlags(offersByWeekCmd)
+}
+
+func runOffersByWeek(cmd *cobra.Command, args []string) error {
+	apiKey, err := loadAshbyEnv("ASHBY_API_KEY")
+	if err != nil {
+		return err
+	}
+	outputJSON := jsonOutput()
+	outputHisto, _ := cmd.Flags().GetBool("histo")
+	includeCurrent, _ := cmd.Flags().GetBool("include-current")
+	histoHeight, histoWeekCount, err := histogramFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	_, jobs, applications, err := fetchAshbyData(cmd.Context(), apiKey, time.Time{})
+	if err != nil {
+		return err
+	}
+
+	progressf("Fetching offers...\n")
+	offers, err := fetchAllOffers(cmd.Context(), apiKey)
+	if err != nil {
+		return fmt.Errorf("failed to fetch offers: %w", err)
+	}
+	progressf("Found %d offers\n\n", len(offers))
+
+	appJobs := make(map[string]string)
+	for _, app := range applications {
+		appJobs[app.ID] = app.Job.ID
+	}
+
+	// Group by job and week, keyed by job ID
+	metrics := make(map[string]*ashbyJobMetrics)
+	for _, offer := range offers {
+		jobID := appJobs[offer.ApplicationID]
+		jobInfo, ok := jobs[jobID]
+		if !ok {
+			// As with applications, unknown jobs keep their own ID when known
+			jobInfo = ashbyJobInfo{Title: "Unknown Job", Department: "No Department"}
+			if jobID != "" {
+				jobInfo.Title = fmt.Sprintf("Unknown Job (%s)", jobID)
+			}
+		}
+
+		if _, ok := metrics[jobID]; !ok {
+			metrics[jobID] = &ashbyJobMetrics{
+				Department: jobInfo.Department,
+				Title:      jobInfo.Title,
+				WeekCounts: make(map[string]int),
+			}
+		}
+		metrics[jobID].WeekCounts[getWeekStart(offer.LatestVersion.CreatedAt)]++
+	}
+
+	weeks := getLastNWeeks(defaultWeekCount())
+
+	if wantTemplateData() {
+		if err := printTemplateGrouped("ashby offers-by-week", metrics, weeks); err != nil || outputFormat == "template" {
+			return err
+		}
+	}
+	if outputHisto {
+		histoWeeks := getLastNWeeks(histoWeekCount)
+		if includeCurrent {
+			histoWeeks = getLastNWeeksIncludingCurrent(histoWeekCount)
+		}
+		printHistogram(metrics, histoWeeks, "Offers", "offers", histoHeight)
+	} else if outputJSON {
+		if err := printJSONGrouped(metrics, weeks, false); err != nil {
+			return err
+		}
+	} else {
+		printTableGrouped(metrics, weeks, "Job", false)
+	}
+	return nil
+}
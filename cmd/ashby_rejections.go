@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// noRejectionReason labels archived applications that have no archive reason.
+const noRejectionReason = "No reason given"
+
+func init() {
+	ashbyCmd.AddCommand(rejectionReasonsCmd)
+	rejectionReasonsCmd.Flags().Bool("json", false, "Output in JSON format")
+}
+
+var rejectionReasonsCmd = &cobra.Command{
+	Use:   "rejection-reasons",
+	Short: "Show archived applications by rejection reason and week",
+	Long: `Fetches all applications and counts archived ones per archive reason per week.
+
+Applications are bucketed by the week they were last updated, which for archived
+applications approximates when they were rejected. Archived applications without
+a reason are grouped under "No reason given".`,
+	RunE: runRejectionReasons,
+}
+
+func runRejectionReasons(cmd *cobra.Command, args []string) error {
+	apiKey, err := loadAshbyEnv("ASHBY_API_KEY")
+	if err != nil {
+		return err
+	}
+	outputJSON := jsonOutput()
+
+	progressf("Fetching applications...\n")
+	applications, err := fetchAllApplications(cmd.Context(), apiKey, time.Time{})
+	if err != nil {
+		return fmt.Errorf("failed to fetch applications: %w", err)
+	}
+	progressf("Found %d applications\n\n", len(applications))
+
+	weeks := getLastNWeeks(defaultWeekCount())
+	currentWeek := getCurrentWeekStart()
+
+	// map[reason]map[week]count
+	reasons := make(map[string]map[string]int)
+	for _, app := range applications {
+		if app.Status != "Archived" {
+			continue
+		}
+		reason := noRejectionReason
+		if app.ArchiveReason != nil && app.ArchiveReason.Text != "" {
+			reason = app.ArchiveReason.Text
+		}
+		if _, ok := reasons[reason]; !ok {
+			reasons[reason] = make(map[string]int)
+		}
+		reasons[reason][getWeekStart(app.UpdatedAt)]++
+	}
+
+	// Sort reasons alphabetically with "No reason given" last
+	var names []string
+	for name := range reasons {
+		if name != noRejectionReason {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if _, ok := reasons[noRejectionReason]; ok {
+		names = append(names, noRejectionReason)
+	}
+
+	if wantTemplateData() {
+		data := newTemplateData("ashby rejection-reasons", "", weeks, currentWeek)
+		for _, name := range names {
+			data.addRow(name, "", reasons[name])
+		}
+		if err := data.output(); err != nil || outputFormat == "template" {
+			return err
+		}
+	}
+
+	if outputJSON {
+		if err := printRejectionReasonsJSON(names, reasons, weeks, currentWeek); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := newWeeklyTable(35, 10, weeks)
+	table.printHeader("Reason", currentWeek)
+	table.printSeparator(currentWeek)
+
+	weekTotals := make(map[string]int)
+	for _, name := range names {
+		label := name
+		if len(label) > table.labelColWidth-2 {
+			label = label[:table.labelColWidth-5] + "..."
+		}
+		table.printRow(label, reasons[name], currentWeek)
+		for _, week := range weeks {
+			weekTotals[week] += reasons[name][week]
+		}
+		weekTotals[currentWeek] += reasons[name][currentWeek]
+	}
+
+	table.printSeparator(currentWeek)
+	table.printTotalsRow("Total", weekTotals, currentWeek)
+	return nil
+}
+
+func printRejectionReasonsJSON(names []string, reasons map[string]map[string]int, weeks []string, currentWeek string) error {
+	var output []RejectionReasonJSON
+	for _, name := range names {
+		data := RejectionReasonJSON{
+			Reason:      name,
+			CurrentWeek: WeekCountJSON{WeekEnding: weekStartToEnd(currentWeek), Count: reasons[name][currentWeek]},
+		}
+		for _, week := range weeks {
+			count := reasons[name][week]
+			data.Weeks = append(data.Weeks, WeekCountJSON{WeekEnding: weekStartToEnd(week), Count: count})
+			data.Total += count
+		}
+		output = append(output, data)
+	}
+
+	return printJSON(output)
+}
@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var ciCmd = &cobra.Command{
+	Use:   "ci [org]/[repo]",
+	Short: "Display GitHub Actions success rates by week for a repository",
+	Long: `Query GitHub Actions workflow runs for a repository and report successful and
+failed runs by week, along with the success rate.
+
+Runs concluding with "failure", "timed_out", or "startup_failure" count as failures.
+Cancelled, skipped, and in-progress runs are ignored.
+
+Use --workflow to limit the report to a single workflow, matched by name or by
+workflow file (e.g. "ci.yml").
+
+Displays counts for the last 4 completed weeks, or as many as weeks in the
+config file sets. Results for completed weeks are cached, so repeated runs
+only fetch the current week; use --refresh to refetch everything.
+
+Requires GITHUB_TOKEN (or github.token in the config file) for API authentication.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCI,
+}
+
+func init() {
+	githubCmd.AddCommand(ciCmd)
+	ciCmd.Flags().Bool("json", false, "Output in JSON format")
+	ciCmd.Flags().String("workflow", "", "Only include runs of this workflow (name or file name)")
+}
+
+type githubWorkflowRun struct {
+	Name       string    `json:"name"`
+	Path       string    `json:"path"`
+	Status     string    `json:"status"`
+	Conclusion string    `json:"conclusion"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type githubWorkflowRunsResponse struct {
+	TotalCount   int                 `json:"total_count"`
+	WorkflowRuns []githubWorkflowRun `json:"workflow_runs"`
+}
+
+type weeklyCIResults struct {
+	Success int
+	Failure int
+}
+
+// successRate returns the percentage of successful runs, or false if there were none.
+func (r weeklyCIResults) successRate() (float64, bool) {
+	completed := r.Success + r.Failure
+	if completed == 0 {
+		return 0, false
+	}
+	return float64(r.Success) / float64(completed) * 100, true
+}
+
+func runCI(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	args, err := argsOrConfig(args, "github.repo", "an org/repo")
+	if err != nil {
+		return err
+	}
+	repo := args[0]
+	outputJSON := jsonOutput()
+	workflow, _ := cmd.Flags().GetString("workflow")
+
+	token := githubToken()
+	if token == "" {
+		return errNoGitHubToken
+	}
+
+	weeks := getLastNWeeks(defaultWeekCount())
+	currentWeek := getCurrentWeekStart()
+
+	// Serve completed weeks from the cache and only fetch from the earliest
+	// week that is missing (normally just the current week).
+	cache := newWeekCache("ci-v2", repo+"@"+workflow)
+	results := make(map[string]*weeklyCIResults)
+	fetchFrom := currentWeek
+	for i := len(weeks) - 1; i >= 0; i-- {
+		results[weeks[i]] = &weeklyCIResults{}
+		if !cache.load(weeks[i], results[weeks[i]]) {
+			fetchFrom = weeks[i]
+		}
+	}
+	for _, week := range weeks {
+		if week >= fetchFrom {
+			results[week] = &weeklyCIResults{}
+		}
+	}
+	results[currentWeek] = &weeklyCIResults{}
+
+	progressf("Fetching workflow runs for %s...\n", repo)
+
+	since, _ := parseWeekStart(fetchFrom)
+	runs, err := fetchWorkflowRuns(ctx, token, repo, since.UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("failed to fetch workflow runs: %w", err)
+	}
+
+	// Bucket completed runs by week
+	matched := 0
+	for _, run := range runs {
+		if workflow != "" && !strings.EqualFold(run.Name, workflow) && !strings.EqualFold(path.Base(run.Path), workflow) {
+			continue
+		}
+		matched++
+		week := getWeekStart(run.CreatedAt)
+		r, ok := results[week]
+		if !ok || week < fetchFrom {
+			continue
+		}
+		switch run.Conclusion {
+		case "success":
+			r.Success++
+		case "failure", "timed_out", "startup_failure":
+			r.Failure++
+		}
+	}
+	for _, week := range weeks {
+		if week >= fetchFrom {
+			cache.store(week, results[week])
+		}
+	}
+	cache.save()
+	// Cached weeks had their runs matched when they were fetched, so only
+	// warn when every week was fetched this time
+	if workflow != "" && matched == 0 && fetchFrom == weeks[0] {
+		stderrf("No runs found for workflow %q\n", workflow)
+	}
+
+	var totals weeklyCIResults
+	for _, week := range weeks {
+		totals.Success += results[week].Success
+		totals.Failure += results[week].Failure
+	}
+
+	if wantTemplateData() {
+		success := make(map[string]int)
+		failure := make(map[string]int)
+		for week, r := range results {
+			success[week] = r.Success
+			failure[week] = r.Failure
+		}
+		data := newTemplateData("github ci", repo, weeks, currentWeek)
+		data.addRow("Success", "", success)
+		data.addRow("Failure", "", failure)
+		if err := data.output(); err != nil || outputFormat == "template" {
+			return err
+		}
+	}
+
+	if outputJSON {
+		return printCIJSON(repo, workflow, weeks, results, currentWeek, totals)
+	}
+
+	title := repo
+	if workflow != "" {
+		title = fmt.Sprintf("%s (%s)", repo, workflow)
+	}
+	printTableTitle("CI Results for %s (%s)", title, describeWeeks(weeks))
+
+	table := newWeeklyTable(20, 10, weeks)
+	table.printHeader("Result", currentWeek)
+	table.printSeparator(currentWeek)
+
+	successCounts := make([]int, len(weeks))
+	failureCounts := make([]int, len(weeks))
+	rates := make([]string, 0, len(weeks)+2)
+	for i, week := range weeks {
+		successCounts[i] = results[week].Success
+		failureCounts[i] = results[week].Failure
+		rates = append(rates, formatPercent(results[week].successRate()))
+	}
+	rates = append(rates, formatPercent(results[currentWeek].successRate()))
+	rates = append(rates, formatPercent(totals.successRate()))
+
+	table.printRowWithSlice("Success", successCounts, results[currentWeek].Success)
+	table.printRowWithSlice("Failure", failureCounts, results[currentWeek].Failure)
+	table.printSeparator(currentWeek)
+	table.printTextRow("Success Rate", rates)
+
+	return nil
+}
+
+// fetchWorkflowRuns returns all workflow runs for repo created on or after
+// since, a date or RFC 3339 timestamp.
+func fetchWorkflowRuns(ctx context.Context, token, repo, since string) ([]githubWorkflowRun, error) {
+	var allRuns []githubWorkflowRun
+	totalCount := 0
+	progress := newFetchProgress("workflow runs")
+	defer progress.done()
+
+	client := newHTTPClient()
+
+	// Follow the Link header until there is no next page
+	next := fmt.Sprintf("https://api.github.com/repos/%s/actions/runs?created=%%3E%%3D%s&per_page=100", repo, since)
+	for next != "" {
+		body, nextURL, err := githubRequestPage(ctx, client, token, next)
+		if errors.Is(err, errGitHubNotFound) {
+			return nil, fmt.Errorf("repository not found: %s", repo)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var response githubWorkflowRunsResponse
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, err
+		}
+
+		writeRaw(body)
+
+		totalCount = response.TotalCount
+		allRuns = append(allRuns, response.WorkflowRuns...)
+		progress.page(len(response.WorkflowRuns))
+		next = nextURL
+	}
+
+	// The API lists at most 1,000 runs for a query and ends its Link header
+	// there, so say so rather than undercount silently
+	if len(allRuns) < totalCount {
+		stderrf("Warning: GitHub listed only %d of %d workflow runs for %s; counts are incomplete\n", len(allRuns), totalCount, repo)
+	}
+	return allRuns, nil
+}
+
+func printCIJSON(repo, workflow string, weeks []string, results map[string]*weeklyCIResults, currentWeek string, totals weeklyCIResults) error {
+	toWeekData := func(weekEnding string, r weeklyCIResults) CIWeekJSON {
+		data := CIWeekJSON{WeekEnding: weekEnding, Success: r.Success, Failure: r.Failure}
+		if rate, ok := r.successRate(); ok {
+			data.SuccessRate = &rate
+		}
+		return data
+	}
+
+	output := CIJSON{Repository: repo, Workflow: workflow}
+	for _, week := range weeks {
+		output.Weeks = append(output.Weeks, toWeekData(weekStartToEnd(week), *results[week]))
+	}
+	output.CurrentWeek = toWeekData(weekStartToEnd(currentWeek), *results[currentWeek])
+	output.Totals = toWeekData("", totals)
+
+	return printJSON(output)
+}
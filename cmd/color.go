@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+)
+
+// ANSI escape sequences used to highlight table output.
+const (
+	ansiReset  = "\033[0m"
+	ansiBold   = "\033[1m"
+	ansiRed    = "\033[31m"
+	ansiYellow = "\033[33m"
+	ansiGray   = "\033[90m"
+)
+
+// colorMode holds the value of the persistent --color flag: auto, always, or never.
+var colorMode string
+
+// noColor holds the value of the persistent --no-color flag.
+var noColor bool
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&colorMode, "color", "auto", "Colorize table output: auto, always, or never")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored table output (same as --color never)")
+}
+
+// validateColorMode checks that --color holds one of the supported values.
+func validateColorMode() error {
+	switch colorMode {
+	case "auto", "always", "never":
+	default:
+		return fmt.Errorf("invalid --color value %q (must be auto, always, or never)", colorMode)
+	}
+	if noColor && colorMode == "always" {
+		return fmt.Errorf("--no-color conflicts with --color always")
+	}
+	return nil
+}
+
+// colorEnabled reports whether ANSI color should be written to stdout.
+// In auto mode color is used only when stdout is a terminal and NO_COLOR is unset.
+func colorEnabled() bool {
+	if noColor {
+		return false
+	}
+	switch colorMode {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	f, ok := stdout.(*os.File)
+	return ok && isTerminal(f)
+}
+
+// isTerminal reports whether f refers to a character device such as a TTY.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// colorize wraps s in the given ANSI code. An empty code returns s unchanged.
+func colorize(code, s string) string {
+	if code == "" {
+		return s
+	}
+	return code + s + ansiReset
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate a shell completion script",
+	Long: `Write a completion script for the given shell to stdout. Hidden commands
+are never offered as completions.
+
+To load completions for the current shell session:
+
+  bash:       source <(scorecard completion bash)
+  zsh:        source <(scorecard completion zsh)
+  fish:       scorecard completion fish | source
+  powershell: scorecard completion powershell | Out-String | Invoke-Expression
+
+To load them for every session, write the script to your shell's completion
+directory instead, e.g.:
+
+  scorecard completion bash > /etc/bash_completion.d/scorecard
+  scorecard completion zsh > "${fpath[1]}/_scorecard"
+  scorecard completion fish > ~/.config/fish/completions/scorecard.fish`,
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	DisableFlagsInUseLine: true,
+	RunE:                  runCompletion,
+}
+
+func init() {
+	// Replace cobra's default completion command with this one
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	rootCmd.AddCommand(completionCmd)
+}
+
+func runCompletion(cmd *cobra.Command, args []string) error {
+	switch args[0] {
+	case "bash":
+		return rootCmd.GenBashCompletionV2(stdout, true)
+	case "zsh":
+		return rootCmd.GenZshCompletion(stdout)
+	case "fish":
+		return rootCmd.GenFishCompletion(stdout, true)
+	case "powershell":
+		return rootCmd.GenPowerShellCompletionWithDesc(stdout)
+	}
+	return fmt.Errorf("unsupported shell %q", args[0])
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// config holds the settings read from the scorecard config file.
+var config = viper.New()
+
+// configFile holds the value of the persistent --config flag.
+var configFile string
+
+// configKeys lists every key recognized in the config file.
+//
+// Settings are applied with the precedence flags, then environment
+// variables, then the config file.
+var configKeys = map[string]bool{
+	"timezone":       true, // time zone for week boundaries; SCORECARD_TZ wins
+	"weeks":          true, // number of completed weeks to report
+	"github.token":   true, // GITHUB_TOKEN wins
+	"github.org":     true, // default GitHub org or user
+	"github.repo":    true, // default org/repo for incidents
+	"ashby.api_key":  true, // ASHBY_API_KEY wins
+	"datum.enabled":  true, // include Datum Cloud metrics
+	"datum.datumctl": true, // path to the datumctl binary
+	"slack.webhook":  true, // Slack incoming webhook URL; SLACK_WEBHOOK_URL wins
+	"all.reports":    true, // reports the all command runs (default: every configured one)
+	"all.orgs":       true, // GitHub orgs for the all command's stars (default: github.org)
+	"all.repos":      true, // repositories for the all command's incidents (default: github.repo)
+}
+
+// envConfigKeys maps environment variables to the config key they override.
+var envConfigKeys = map[string]string{
+	"GITHUB_TOKEN":  "github.token",
+	"ASHBY_API_KEY": "ashby.api_key",
+	"SCORECARD_TZ":  "timezone",
+
+	"SLACK_WEBHOOK_URL": "slack.webhook",
+}
+
+// errNoGitHubToken is returned by commands that need a GitHub token when
+// neither GITHUB_TOKEN nor github.token is set and `gh auth token` fails.
+var errNoGitHubToken = errors.New("GITHUB_TOKEN not set (set it in the environment or as github.token in the config file, or log in with `gh auth login`)")
+
+var (
+	githubTokenOnce  sync.Once
+	githubTokenValue string
+)
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "Config file (default: <user config dir>/scorecard/config.yaml)")
+}
+
+// defaultConfigFile returns the default location of the config file.
+func defaultConfigFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine config directory: %w", err)
+	}
+	return filepath.Join(dir, "scorecard", "config.yaml"), nil
+}
+
+// loadConfig reads the config file named by --config, or the default file.
+// It returns the path that was read, or "" when the default file does not
+// exist. A missing --config file is an error.
+func loadConfig() (string, error) {
+	path := configFile
+	if path == "" {
+		var err error
+		path, err = defaultConfigFile()
+		if err != nil {
+			return "", err
+		}
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+	}
+
+	config.SetConfigFile(path)
+	if err := config.ReadInConfig(); err != nil {
+		return "", fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// envOrConfig returns the environment variable envVar, or the config value it
+// overrides (see envConfigKeys) when the variable is unset.
+func envOrConfig(envVar string) string {
+	if v := os.Getenv(envVar); v != "" {
+		return v
+	}
+	return config.GetString(envConfigKeys[envVar])
+}
+
+// githubToken returns the GitHub token from GITHUB_TOKEN or github.token,
+// falling back to the token of the gh CLI. The source is reported to stderr
+// the first time a token is looked up.
+func githubToken() string {
+	githubTokenOnce.Do(func() {
+		switch {
+		case os.Getenv("GITHUB_TOKEN") != "":
+			githubTokenValue = os.Getenv("GITHUB_TOKEN")
+			progressf("Using GitHub token from GITHUB_TOKEN\n")
+		case config.GetString("github.token") != "":
+			githubTokenValue = config.GetString("github.token")
+			progressf("Using GitHub token from github.token in the config file\n")
+		default:
+			if token, err := ghAuthToken(); err == nil {
+				githubTokenValue = token
+				progressf("Using GitHub token from `gh auth token`\n")
+			}
+		}
+	})
+	return githubTokenValue
+}
+
+// ghAuthToken returns the token the gh CLI is logged in with.
+func ghAuthToken() (string, error) {
+	path, err := exec.LookPath("gh")
+	if err != nil {
+		return "", fmt.Errorf("gh not found in PATH")
+	}
+	out, err := exec.Command(path, "auth", "token").Output()
+	if err != nil {
+		return "", fmt.Errorf("gh auth token: %w", err)
+	}
+	token := strings.TrimSpace(string(out))
+	if token == "" {
+		return "", fmt.Errorf("gh auth token returned no token")
+	}
+	return token, nil
+}
+
+// defaultWeekCount returns the configured number of completed weeks to
+// report, or 4 when the config file does not set one.
+func defaultWeekCount() int {
+	if n := config.GetInt("weeks"); n > 0 {
+		return n
+	}
+	return 4
+}
+
+// weeksFlag returns the --weeks flag of cmd, falling back to the config
+// file's weeks when the flag was not given.
+func weeksFlag(cmd *cobra.Command) int {
+	n, _ := cmd.Flags().GetInt("weeks")
+	if !cmd.Flags().Changed("weeks") && config.IsSet("weeks") {
+		n = config.GetInt("weeks")
+	}
+	return n
+}
+
+// argsOrConfig returns args, or the config value for key as the only argument
+// when no arguments were given. what describes the missing argument for the
+// error, e.g. "an org or user".
+func argsOrConfig(args []string, key, what string) ([]string, error) {
+	if len(args) > 0 {
+		return args, nil
+	}
+	if v := config.GetString(key); v != "" {
+		return []string{v}, nil
+	}
+	return nil, fmt.Errorf("requires %s argument (or %s in the config file)", what, key)
+}
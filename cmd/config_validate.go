@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the scorecard config file",
+	Long:  "Commands for working with the scorecard config file.",
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the config file for mistakes",
+	Long: `Parse the config file and report problems that would otherwise cause
+silent misbehavior:
+  - unknown keys (usually typos)
+  - invalid values, such as an unknown timezone or a malformed repository
+  - missing required settings for each configured integration
+    (github: a token and an org or repo; ashby: an API key;
+     datum: a reachable datumctl)
+
+Tokens may also come from GITHUB_TOKEN and ASHBY_API_KEY. Use
+--check-connectivity to also call each configured API and confirm the targets
+exist and the credentials work.
+
+Exits non-zero when any problem is found, so it can gate scheduled reports in CI.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigValidate,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configValidateCmd.Flags().Bool("check-connectivity", false, "Call each configured API to check targets and credentials")
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	checkConnectivity, _ := cmd.Flags().GetBool("check-connectivity")
+
+	path, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		def, _ := defaultConfigFile()
+		return fmt.Errorf("no config file found at %s (use --config to choose one)", def)
+	}
+	fmt.Fprintf(stdout, "Validating %s\n\n", path)
+
+	var problems []string
+	report := func(format string, a ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, a...))
+	}
+
+	// Unknown keys
+	for _, key := range config.AllKeys() {
+		if !configKeys[key] {
+			report("unknown key %q", key)
+		}
+	}
+
+	// Value checks
+	if config.IsSet("timezone") {
+		if _, err := time.LoadLocation(config.GetString("timezone")); err != nil {
+			report("timezone: %v", err)
+		}
+	}
+	if config.IsSet("weeks") && config.GetInt("weeks") < 1 {
+		report("weeks: must be at least 1, got %q", config.GetString("weeks"))
+	}
+
+	// GitHub
+	if config.IsSet("github") {
+		token := config.GetString("github.token")
+		if token == "" {
+			token = githubToken()
+		}
+		org, repo := config.GetString("github.org"), config.GetString("github.repo")
+		if token == "" {
+			report("github: no token (set github.token or GITHUB_TOKEN, or log in with gh)")
+		}
+		if org == "" && repo == "" {
+			report("github: set at least one of github.org or github.repo")
+		}
+		if repo != "" && strings.Count(repo, "/") != 1 {
+			report("github.repo: %q is not of the form org/repo", repo)
+		}
+		if checkConnectivity && token != "" {
+			if org != "" {
+				if _, err := fetchGitHubOwnerType(ctx, token, org); err != nil {
+					report("github.org: %v", err)
+				}
+			}
+			if repo != "" && strings.Count(repo, "/") == 1 {
+				if _, err := githubRequest(ctx, newHTTPClient(), token, "https://api.github.com/repos/"+repo); err != nil {
+					report("github.repo: %s: %v", repo, err)
+				}
+			}
+		}
+	}
+
+	// Ashby
+	if config.IsSet("ashby") {
+		apiKey := config.GetString("ashby.api_key")
+		if apiKey == "" {
+			apiKey = os.Getenv("ASHBY_API_KEY")
+		}
+		if apiKey == "" {
+			report("ashby: no API key (set ashby.api_key or ASHBY_API_KEY)")
+		} else if checkConnectivity {
+			body, err := ashbyRequest(cmd.Context(), apiKey, "department.list", map[string]interface{}{"limit": 1})
+			var status ashbyStatus
+			if err == nil {
+				if err = json.Unmarshal(body, &status); err == nil {
+					err = status.err()
+				}
+			}
+			if err != nil {
+				report("ashby: %v", err)
+			}
+		}
+	}
+
+	// Datum
+	if config.GetBool("datum.enabled") {
+		if datumctl := config.GetString("datum.datumctl"); datumctl != "" {
+			if _, err := os.Stat(datumctl); err != nil {
+				report("datum.datumctl: %v", err)
+			}
+		} else if _, err := findDatumctl(); err != nil {
+			report("datum: %v", err)
+		}
+	}
+
+	if len(problems) == 0 {
+		fmt.Fprintln(stdout, "No problems found")
+		return nil
+	}
+
+	sort.Strings(problems)
+	for _, p := range problems {
+		fmt.Fprintf(stdout, "  - %s\n", p)
+	}
+	fmt.Fprintln(stdout)
+	return fmt.Errorf("%d problem(s) found in %s", len(problems), path)
+}
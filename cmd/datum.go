@@ -1,7 +1,10 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -20,14 +23,25 @@ var datumCmd = &cobra.Command{
 
 var activeUsersCmd = &cobra.Command{
 	Use:   "active-users",
-	Short: "Count active users by week over the last 4 weeks",
+	Short: "Count active users by week over recent weeks",
 	Long: `Query Datum Cloud audit logs to count unique users who have created or modified
-resources, broken down by week over the last 4 completed weeks.
+resources, broken down by week over the last 4 completed weeks. Use --weeks N
+to look further back, e.g. --weeks 13 for a quarterly trend; the audit log
+query then reaches back to the start of the first of those weeks.
 
 Requires datumctl to be installed and authenticated (run 'datumctl auth login').
 
+Audit log queries can be slow; use --max-cache-age (e.g. 1h) to reuse the
+output of an identical recent query, and --no-cache to force a fresh one.
+
 Active users are those who performed create, update, or patch operations.
-System accounts are excluded from the count.`,
+System accounts (system: usernames and users without a UID) and activity in
+the activity.miloapis.com API group are excluded from the count. Use
+--exclude-group (repeatable) to choose which API groups are excluded instead,
+and --include-system to count system accounts and drop the default group
+exclusion, e.g. when debugging. Use --by-verb to also show the
+unique users for each verb; a user active with several verbs is counted once
+in the Active Users total.`,
 	RunE: runActiveUsers,
 }
 
@@ -35,6 +49,10 @@ func init() {
 	rootCmd.AddCommand(datumCmd)
 	datumCmd.AddCommand(activeUsersCmd)
 	activeUsersCmd.Flags().Bool("json", false, "Output in JSON format")
+	activeUsersCmd.Flags().Int("weeks", 4, "Number of completed weeks to show (1-52)")
+	activeUsersCmd.Flags().Bool("by-verb", false, "Show unique users per verb (create, update, patch) as separate rows")
+	activeUsersCmd.Flags().Bool("include-system", false, "Include system accounts and the activity API group")
+	activeUsersCmd.Flags().StringArray("exclude-group", nil, "API group to exclude (repeatable, default: activity.miloapis.com)")
 	activeUsersCmd.Flags().Int("limit", 0, "Limit number of audit events to fetch (0 = all)")
 }
 
@@ -43,7 +61,11 @@ type auditEvent struct {
 		Username string `json:"username"`
 		UID      string `json:"uid"`
 	} `json:"user"`
-	Verb                     string `json:"verb"`
+	Verb      string `json:"verb"`
+	ObjectRef struct {
+		Resource string `json:"resource"`
+		APIGroup string `json:"apiGroup"`
+	} `json:"objectRef"`
 	RequestReceivedTimestamp string `json:"requestReceivedTimestamp"`
 }
 
@@ -52,6 +74,14 @@ type auditQueryResult struct {
 }
 
 func findDatumctl() (string, error) {
+	// A path in the config file wins
+	if path := config.GetString("datum.datumctl"); path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return "", fmt.Errorf("datum.datumctl: %w", err)
+		}
+		return path, nil
+	}
+
 	// Prefer ~/bin/datumctl if it exists
 	home, err := os.UserHomeDir()
 	if err == nil {
@@ -70,30 +100,182 @@ func findDatumctl() (string, error) {
 }
 
 func runActiveUsers(cmd *cobra.Command, args []string) error {
-	outputJSON, _ := cmd.Flags().GetBool("json")
+	ctx := cmd.Context()
+	outputJSON := jsonOutput()
 	limit, _ := cmd.Flags().GetInt("limit")
+	byVerb, _ := cmd.Flags().GetBool("by-verb")
+	numWeeks := weeksFlag(cmd)
+	if numWeeks < 1 || numWeeks > 52 {
+		return fmt.Errorf("--weeks must be between 1 and 52, got %d", numWeeks)
+	}
+
+	filter := defaultAuditFilter
+	filter.IncludeSystem, _ = cmd.Flags().GetBool("include-system")
+	if filter.IncludeSystem {
+		filter.ExcludeGroups = nil
+	}
+	if cmd.Flags().Changed("exclude-group") {
+		filter.ExcludeGroups, _ = cmd.Flags().GetStringArray("exclude-group")
+	}
 
 	datumctl, err := findDatumctl()
 	if err != nil {
 		return err
 	}
 
-	weeks := getLast4Weeks()
+	weeks := getLastNWeeks(numWeeks)
 	if len(weeks) == 0 {
 		return fmt.Errorf("failed to calculate weeks")
 	}
 	currentWeek := getCurrentWeekStart()
 
-	fmt.Fprintln(os.Stderr, "Querying Datum Cloud audit logs for the last 4 weeks...")
+	progressf("Querying Datum Cloud audit logs for the last %d weeks...\n", numWeeks)
+
+	events, err := queryAuditEvents(ctx, datumctl, limit, weeks, filter)
+	if err != nil {
+		return err
+	}
+	sets := groupActiveUsers(events, weeks, currentWeek)
+	weekCounts, totalUsers := sets.counts()
+
+	if wantTemplateData() {
+		data := newTemplateData("datum active-users", "", weeks, currentWeek)
+		data.addRow("Active Users", "", weekCounts)
+		if byVerb {
+			for _, verb := range activeUserVerbs {
+				data.addRow(verb, "verb", sets.verbCounts(verb))
+			}
+		}
+		data.Summary["total_unique_users"] = totalUsers
+		if err := data.output(); err != nil || outputFormat == "template" {
+			return err
+		}
+	}
+
+	if outputJSON {
+		toWeekData := func(week string) ActiveUsersWeekJSON {
+			data := ActiveUsersWeekJSON{WeekEnding: weekStartToEnd(week), ActiveUsers: weekCounts[week]}
+			if byVerb {
+				data.Verbs = make(map[string]int)
+				for _, verb := range activeUserVerbs {
+					data.Verbs[verb] = len(sets[week][verb])
+				}
+			}
+			return data
+		}
+
+		var weeksData []ActiveUsersWeekJSON
+		for _, week := range weeks {
+			weeksData = append(weeksData, toWeekData(week))
+		}
+
+		out := ActiveUsersJSON{
+			Weeks:       weeksData,
+			CurrentWeek: toWeekData(currentWeek),
+			TotalUsers:  totalUsers,
+		}
+
+		if err := printJSON(out); err != nil {
+			return err
+		}
+	} else {
+		table := newWeeklyTable(20, 10, weeks)
+		table.printHeader("Metric", currentWeek)
+		table.printSeparator(currentWeek)
+		if byVerb {
+			// A user active with several verbs counts once in the total row
+			for _, verb := range activeUserVerbs {
+				table.printRow(verb, sets.verbCounts(verb), currentWeek)
+			}
+			table.printSeparator(currentWeek)
+		}
+		table.printRow("Active Users", weekCounts, currentWeek)
+		table.printSeparator(currentWeek)
+		printTableNote("\nTotal Unique Users: %d\n", totalUsers)
+	}
+
+	return nil
+}
+
+// activeUserVerbs are the write operations that make a user active.
+var activeUserVerbs = []string{"create", "update", "patch"}
+
+// countActiveUsersByWeek queries the Datum Cloud audit logs via datumctl and
+// returns the number of unique active users for each of the given weeks and
+// the current week, along with the number of unique users across all of them.
+func countActiveUsersByWeek(ctx context.Context, datumctl string, limit int, weeks []string, currentWeek string) (map[string]int, int, error) {
+	events, err := queryAuditEvents(ctx, datumctl, limit, weeks, defaultAuditFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+	weekCounts, total := groupActiveUsers(events, weeks, currentWeek).counts()
+	return weekCounts, total, nil
+}
+
+// auditFilter selects which write operations a datumctl query returns.
+type auditFilter struct {
+	IncludeSystem bool     // also count system accounts
+	ExcludeGroups []string // API groups whose activity is ignored
+}
+
+// defaultAuditFilter counts real users and ignores the activity API group,
+// whose writes are generated by the audit system itself.
+var defaultAuditFilter = auditFilter{ExcludeGroups: []string{"activity.miloapis.com"}}
+
+// String returns the CEL filter expression passed to datumctl.
+func (f auditFilter) String() string {
+	clauses := []string{"verb in [" + quoteCELList(activeUserVerbs) + "]"}
+	if !f.IncludeSystem {
+		clauses = append(clauses, "user.username.contains('system:') == false", "user.uid != ''")
+	}
+	var groups []string
+	for _, g := range f.ExcludeGroups {
+		if g = strings.TrimSpace(g); g != "" {
+			groups = append(groups, g)
+		}
+	}
+	if len(groups) > 0 {
+		clauses = append(clauses, "objectRef.apiGroup in ["+quoteCELList(groups)+"] == false")
+	}
+	return strings.Join(clauses, " && ")
+}
+
+// quoteCELList renders values as a comma-separated list of CEL string literals.
+func quoteCELList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(v) + "'"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// queryAuditEvents runs a datumctl activity query for write operations
+// matching filter, reaching back to the start of the first of weeks. For N
+// completed weeks plus the current one that is at most 7N+7 days.
+func queryAuditEvents(ctx context.Context, datumctl string, limit int, weeks []string, filter auditFilter) ([]auditEvent, error) {
+	days := 30
+	start := timeNow().UTC().AddDate(0, 0, -days)
+	if len(weeks) > 0 {
+		if first, err := parseWeekStart(weeks[0]); err == nil {
+			days = int(timeNow().Sub(first).Hours()/24) + 1
+			start = first
+		}
+	}
+
+	cachePath := datumCachePath(filter, start, limit)
+	if cached, ok := readDatumCache(cachePath); ok && !dryRun {
+		var result auditQueryResult
+		if err := json.Unmarshal(cached, &result); err == nil {
+			progressf("Using cached audit log query from %s\n", cachePath)
+			return result.Items, nil
+		}
+	}
 
-	// Query audit logs for the last ~30 days (covers 4 weeks + current week)
-	// Filter for write operations by real users (excluding system accounts)
-	filter := "verb in ['create', 'update', 'patch'] && user.username.contains('system:') == false && user.uid != '' && objectRef.apiGroup in ['activity.miloapis.com'] == false"
 	queryArgs := []string{"activity", "query",
 		"--platform-wide",
-		"--start-time", "now-30d",
+		"--start-time", fmt.Sprintf("now-%dd", days),
 		"--end-time", "now",
-		"--filter", filter,
+		"--filter", filter.String(),
 		"-o", "json",
 	}
 	if limit > 0 {
@@ -101,37 +283,76 @@ func runActiveUsers(cmd *cobra.Command, args []string) error {
 	} else {
 		queryArgs = append(queryArgs, "--all-pages")
 	}
-	queryCmd := exec.Command(datumctl, queryArgs...)
+	if dryRun {
+		dryRunf("%s %s", datumctl, shellQuote(queryArgs))
+		return nil, nil
+	}
+	queryCmd := exec.CommandContext(ctx, datumctl, queryArgs...)
+	var stderr bytes.Buffer
+	queryCmd.Stderr = &stderr
 
+	began := time.Now()
 	output, err := queryCmd.Output()
+	logger.Debug("datumctl query", "args", shellQuote(queryArgs), "bytes", len(output), "elapsed", time.Since(began).Round(time.Millisecond))
 	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			stderr := string(exitErr.Stderr)
-			// Detect auth-related failures
-			if strings.Contains(stderr, "oauth2") ||
-				strings.Contains(stderr, "token") ||
-				strings.Contains(stderr, "nil context") ||
-				strings.Contains(stderr, "credentials") {
-				return fmt.Errorf("authentication error: please run 'datumctl auth login' and try again")
+		// A killed datumctl is reported as the cancellation that killed it
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if _, ok := err.(*exec.ExitError); ok {
+			if isDatumAuthError(stderr.String()) {
+				return nil, errDatumAuth
 			}
-			return fmt.Errorf("datumctl query failed: %s", stderr)
+			return nil, fmt.Errorf("datumctl query failed: %s", stderr.String())
 		}
-		return fmt.Errorf("failed to run datumctl: %w", err)
+		return nil, fmt.Errorf("failed to run datumctl: %w", err)
 	}
 
+	// An expired session can exit 0 after writing a login prompt to stdout
+	// instead of JSON, so check both streams before parsing
 	var result auditQueryResult
-	if err := json.Unmarshal(output, &result); err != nil {
-		return fmt.Errorf("failed to parse audit log response: %w", err)
+	trimmed := bytes.TrimSpace(output)
+	if !bytes.HasPrefix(trimmed, []byte("{")) || json.Unmarshal(trimmed, &result) != nil {
+		combined := string(output) + "\n" + stderr.String()
+		if isDatumAuthError(combined) {
+			return nil, errDatumAuth
+		}
+		if err := json.Unmarshal(trimmed, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse audit log response: %w", err)
+		}
+	}
+	writeCacheFile(cachePath, output)
+	return result.Items, nil
+}
+
+// errDatumAuth is returned when datumctl is not logged in or its session has
+// expired.
+var errDatumAuth = errors.New("authentication error: please run 'datumctl auth login' and try again")
+
+// isDatumAuthError reports whether datumctl output describes a missing or
+// expired login.
+func isDatumAuthError(output string) bool {
+	lower := strings.ToLower(output)
+	for _, marker := range []string{"oauth2", "token", "nil context", "credentials", "auth login", "log in", "login required", "unauthorized", "expired"} {
+		if strings.Contains(lower, marker) {
+			return true
+		}
 	}
+	return false
+}
+
+// activeUserSets holds the set of active usernames for each week and verb.
+type activeUserSets map[string]map[string]map[string]struct{}
 
-	// Group users by week (including current week)
-	weekUsers := make(map[string]map[string]struct{})
-	for _, week := range weeks {
-		weekUsers[week] = make(map[string]struct{})
+// groupActiveUsers buckets events by week (the given weeks plus the current
+// week) and verb. Events outside those weeks are ignored.
+func groupActiveUsers(events []auditEvent, weeks []string, currentWeek string) activeUserSets {
+	sets := make(activeUserSets)
+	for _, week := range append(append([]string{}, weeks...), currentWeek) {
+		sets[week] = make(map[string]map[string]struct{})
 	}
-	weekUsers[currentWeek] = make(map[string]struct{})
 
-	for _, event := range result.Items {
+	for _, event := range events {
 		username := event.User.Username
 		if username == "" {
 			continue
@@ -142,62 +363,43 @@ func runActiveUsers(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			continue
 		}
-		weekStart := getWeekStart(t)
 
 		// Only count if this week is in our range
-		if users, ok := weekUsers[weekStart]; ok {
-			users[username] = struct{}{}
+		verbs, ok := sets[getWeekStart(t)]
+		if !ok {
+			continue
 		}
+		if verbs[event.Verb] == nil {
+			verbs[event.Verb] = make(map[string]struct{})
+		}
+		verbs[event.Verb][username] = struct{}{}
 	}
+	return sets
+}
 
-	// Count unique users per week
+// counts returns the number of unique users per week across all verbs, and
+// across all weeks.
+func (s activeUserSets) counts() (map[string]int, int) {
 	weekCounts := make(map[string]int)
 	allUsers := make(map[string]struct{})
-	for week, users := range weekUsers {
-		weekCounts[week] = len(users)
-		for user := range users {
-			allUsers[user] = struct{}{}
+	for week, verbs := range s {
+		weekUsers := make(map[string]struct{})
+		for _, users := range verbs {
+			for user := range users {
+				weekUsers[user] = struct{}{}
+				allUsers[user] = struct{}{}
+			}
 		}
+		weekCounts[week] = len(weekUsers)
 	}
+	return weekCounts, len(allUsers)
+}
 
-	if outputJSON {
-		type WeekData struct {
-			WeekEnding  string `json:"week_ending"`
-			ActiveUsers int    `json:"active_users"`
-		}
-		type jsonOutput struct {
-			Weeks       []WeekData `json:"weeks"`
-			CurrentWeek WeekData   `json:"current_week"`
-			TotalUsers  int        `json:"total_unique_users"`
-		}
-
-		var weeksData []WeekData
-		for _, week := range weeks {
-			weeksData = append(weeksData, WeekData{
-				WeekEnding:  weekStartToEnd(week),
-				ActiveUsers: weekCounts[week],
-			})
-		}
-
-		out := jsonOutput{
-			Weeks: weeksData,
-			CurrentWeek: WeekData{
-				WeekEnding:  weekStartToEnd(currentWeek),
-				ActiveUsers: weekCounts[currentWeek],
-			},
-			TotalUsers: len(allUsers),
-		}
-
-		b, _ := json.MarshalIndent(out, "", "  ")
-		fmt.Println(string(b))
-	} else {
-		table := newWeeklyTable(20, 10, weeks)
-		table.printHeader("Metric", currentWeek)
-		table.printSeparator(currentWeek)
-		table.printRow("Active Users", weekCounts, currentWeek)
-		table.printSeparator(currentWeek)
-		fmt.Printf("\nTotal Unique Users: %d\n", len(allUsers))
+// verbCounts returns the number of unique users per week for one verb.
+func (s activeUserSets) verbCounts(verb string) map[string]int {
+	counts := make(map[string]int)
+	for week, verbs := range s {
+		counts[week] = len(verbs[verb])
 	}
-
-	return nil
+	return counts
 }
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+var (
+	// datumCacheDir is where cached datumctl query output is stored.
+	datumCacheDir string
+
+	// datumMaxCacheAge enables the query cache when non-zero: output younger
+	// than this is served from disk instead of running datumctl again.
+	datumMaxCacheAge time.Duration
+
+	// datumNoCache forces a fresh query, still refreshing the cache.
+	datumNoCache bool
+)
+
+func init() {
+	datumCmd.PersistentFlags().StringVar(&datumCacheDir, "cache-dir", "", "Directory for cached datumctl output (default: <user cache dir>/scorecard/datum)")
+	datumCmd.PersistentFlags().DurationVar(&datumMaxCacheAge, "max-cache-age", 0, "Reuse cached datumctl output younger than this, e.g. 1h (0 = no caching)")
+	datumCmd.PersistentFlags().BoolVar(&datumNoCache, "no-cache", false, "Ignore cached datumctl output and query again")
+}
+
+// datumCachePath returns the cache file for one audit query, keyed by a hash
+// of the filter, the first day queried and the time zone it begins in, and the
+// event limit so that different queries never share an entry. It returns "" when caching is off.
+func datumCachePath(filter auditFilter, start time.Time, limit int) string {
+	if datumMaxCacheAge <= 0 {
+		return ""
+	}
+	dir := datumCacheDir
+	if dir == "" {
+		base, err := os.UserCacheDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(base, "scorecard", "datum")
+	}
+	key := fmt.Sprintf("%s\x00%s\x00%s\x00%d", filter, start.Format("2006-01-02"), weekLocation, limit)
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(dir, "activity-"+hex.EncodeToString(sum[:12])+".json")
+}
+
+// readDatumCache returns the cached output at path if it is younger than
+// --max-cache-age and --no-cache is not set.
+func readDatumCache(path string) ([]byte, bool) {
+	if datumNoCache {
+		return nil, false
+	}
+	return readCacheFile(path, datumMaxCacheAge)
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var resourceActivityCmd = &cobra.Command{
+	Use:   "resource-activity",
+	Short: "Count write operations by resource type and week",
+	Long: `Query Datum Cloud audit logs and count create, update, and patch operations
+per resource type for each of the last 4 completed weeks (use --weeks to change
+the window), showing which kinds of resources are being changed most.
+
+Resources are labeled resource.apiGroup (e.g. projects.resourcemanager.miloapis.com),
+or just the resource name for the core API group, and sorted by total
+operations. System accounts are excluded, as in 'datum active-users'.
+
+Requires datumctl to be installed and authenticated (run 'datumctl auth login').`,
+	Args: cobra.NoArgs,
+	RunE: runResourceActivity,
+}
+
+func init() {
+	datumCmd.AddCommand(resourceActivityCmd)
+	resourceActivityCmd.Flags().Bool("json", false, "Output in JSON format")
+	resourceActivityCmd.Flags().Int("weeks", 4, "Number of completed weeks to show (1-52)")
+	resourceActivityCmd.Flags().Int("limit", 0, "Limit number of audit events to fetch (0 = all)")
+}
+
+// resourceLabel names the resource type an audit event acted on.
+func resourceLabel(event auditEvent) string {
+	ref := event.ObjectRef
+	switch {
+	case ref.Resource == "":
+		return "(unknown)"
+	case ref.APIGroup == "":
+		return ref.Resource
+	}
+	return ref.Resource + "." + ref.APIGroup
+}
+
+func runResourceActivity(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	outputJSON := jsonOutput()
+	numWeeks := weeksFlag(cmd)
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	if numWeeks < 1 || numWeeks > 52 {
+		return fmt.Errorf("--weeks must be between 1 and 52, got %d", numWeeks)
+	}
+
+	datumctl, err := findDatumctl()
+	if err != nil {
+		return err
+	}
+
+	weeks := getLastNWeeks(numWeeks)
+	currentWeek := getCurrentWeekStart()
+
+	progressf("Querying Datum Cloud audit logs for the last %d weeks...\n", numWeeks)
+	events, err := queryAuditEvents(ctx, datumctl, limit, weeks, defaultAuditFilter)
+	if err != nil {
+		return err
+	}
+
+	// Count operations by resource and week, keeping only displayed weeks
+	inRange := make(map[string]bool)
+	for _, week := range weeks {
+		inRange[week] = true
+	}
+	inRange[currentWeek] = true
+
+	counts := make(map[string]map[string]int)
+	for _, event := range events {
+		t, err := time.Parse(time.RFC3339, event.RequestReceivedTimestamp)
+		if err != nil {
+			continue
+		}
+		week := getWeekStart(t)
+		if !inRange[week] {
+			continue
+		}
+		label := resourceLabel(event)
+		if counts[label] == nil {
+			counts[label] = make(map[string]int)
+		}
+		counts[label][week]++
+	}
+
+	// Busiest resources first, by completed-week total
+	totals := make(map[string]int)
+	var resources []string
+	for label, byWeek := range counts {
+		for _, week := range weeks {
+			totals[label] += byWeek[week]
+		}
+		resources = append(resources, label)
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		if totals[resources[i]] != totals[resources[j]] {
+			return totals[resources[i]] > totals[resources[j]]
+		}
+		return resources[i] < resources[j]
+	})
+
+	if wantTemplateData() {
+		data := newTemplateData("datum resource-activity", "", weeks, currentWeek)
+		for _, label := range resources {
+			data.addRow(label, "", counts[label])
+		}
+		if err := data.output(); err != nil || outputFormat == "template" {
+			return err
+		}
+	}
+
+	if outputJSON {
+		return printResourceActivityJSON(resources, counts, weeks, currentWeek)
+	}
+
+	printTableTitle("Write Operations by Resource (%s)", describeWeeks(weeks))
+
+	labelWidth := 30
+	for _, label := range resources {
+		if len(label)+2 > labelWidth {
+			labelWidth = len(label) + 2
+		}
+	}
+	table := newWeeklyTable(labelWidth, 10, weeks)
+	table.printHeader("Resource", currentWeek)
+	table.printSeparator(currentWeek)
+
+	weekTotals := make(map[string]int)
+	for _, label := range resources {
+		table.printRow(label, counts[label], currentWeek)
+		for week, n := range counts[label] {
+			weekTotals[week] += n
+		}
+	}
+	table.printSeparator(currentWeek)
+	table.printTotalsRow("Total", weekTotals, currentWeek)
+
+	return nil
+}
+
+func printResourceActivityJSON(resources []string, counts map[string]map[string]int, weeks []string, currentWeek string) error {
+	output := []ResourceActivityJSON{}
+	for _, label := range resources {
+		data := ResourceActivityJSON{
+			Resource:    label,
+			CurrentWeek: ResourceWeekJSON{WeekEnding: weekStartToEnd(currentWeek), Operations: counts[label][currentWeek]},
+		}
+		for _, week := range weeks {
+			data.Weeks = append(data.Weeks, ResourceWeekJSON{WeekEnding: weekStartToEnd(week), Operations: counts[label][week]})
+			data.Total += counts[label][week]
+		}
+		output = append(output, data)
+	}
+
+	return printJSON(output)
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var topUsersCmd = &cobra.Command{
+	Use:   "top-users",
+	Short: "List the Datum Cloud users with the most write operations",
+	Long: `Query Datum Cloud audit logs and count the create, update, and patch
+operations performed by each user since the start of the last 4 completed
+weeks (use --weeks to change the window), then list the most active users.
+
+Requires datumctl to be installed and authenticated (run 'datumctl auth login').
+
+System accounts are excluded, as in 'datum active-users'.`,
+	Args: cobra.NoArgs,
+	RunE: runTopUsers,
+}
+
+func init() {
+	datumCmd.AddCommand(topUsersCmd)
+	topUsersCmd.Flags().Bool("json", false, "Output in JSON format")
+	topUsersCmd.Flags().Int("top", 20, "Number of users to show (0 = all)")
+	topUsersCmd.Flags().Int("weeks", 4, "Number of completed weeks to look back, plus the current week (1-52)")
+	topUsersCmd.Flags().Int("limit", 0, "Limit number of audit events to fetch (0 = all)")
+}
+
+func runTopUsers(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	outputJSON := jsonOutput()
+	top, _ := cmd.Flags().GetInt("top")
+	numWeeks := weeksFlag(cmd)
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	if top < 0 {
+		return fmt.Errorf("--top must not be negative")
+	}
+	if numWeeks < 1 || numWeeks > 52 {
+		return fmt.Errorf("--weeks must be between 1 and 52, got %d", numWeeks)
+	}
+
+	datumctl, err := findDatumctl()
+	if err != nil {
+		return err
+	}
+
+	progressf("Querying Datum Cloud audit logs for the last %d weeks...\n", numWeeks)
+	events, err := queryAuditEvents(ctx, datumctl, limit, getLastNWeeks(numWeeks), defaultAuditFilter)
+	if err != nil {
+		return err
+	}
+
+	tally := make(map[string]int)
+	for _, event := range events {
+		if event.User.Username != "" {
+			tally[event.User.Username]++
+		}
+	}
+
+	users := make([]TopUserJSON, 0, len(tally))
+	for username, n := range tally {
+		users = append(users, TopUserJSON{Username: username, Operations: n})
+	}
+	sort.Slice(users, func(i, j int) bool {
+		if users[i].Operations != users[j].Operations {
+			return users[i].Operations > users[j].Operations
+		}
+		return users[i].Username < users[j].Username
+	})
+	if top > 0 && top < len(users) {
+		users = users[:top]
+	}
+
+	if outputJSON {
+		return printJSON(users)
+	}
+
+	if gridFormat() {
+		var rows [][]string
+		for _, u := range users {
+			rows = append(rows, []string{u.Username, strconv.Itoa(u.Operations)})
+		}
+		printGrid([]string{"User", "Operations"}, rows)
+		return nil
+	}
+
+	fmt.Fprintf(stdout, "%-50s %12s\n", "User", "Operations")
+	fmt.Fprintln(stdout, strings.Repeat("=", 63))
+	for _, u := range users {
+		fmt.Fprintf(stdout, "%-50s %12d\n", u.Username, u.Operations)
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var describeCmd = &cobra.Command{
+	Use:   "describe [command]",
+	Short: "Print the JSON schema of a command's JSON output",
+	Long: `Prints a JSON Schema for the document a command writes with --output json,
+without making any API calls. Name the command as you would run it, e.g.
+
+  scorecard describe github ci
+  scorecard describe github stars --by-language
+
+Without a command, lists the commands that can be described.
+
+With --output jsonl, a command whose document is a list writes one element per
+line; other documents name the list they stream in their description.`,
+	// Flags are part of the described command's name, e.g. --mttr
+	DisableFlagParsing: true,
+	RunE:               runDescribe,
+}
+
+func init() {
+	rootCmd.AddCommand(describeCmd)
+}
+
+// describedOutput is a command's JSON output as listed by describe.
+type describedOutput struct {
+	command     string        // command path, plus any flag that changes the document
+	description string        // what the document holds
+	docs        []interface{} // zero values of the document types; several are alternatives
+}
+
+// jsonSchemas lists every command's JSON output document.
+var jsonSchemas = []describedOutput{
+	{"all", "Each report's own document under data, or its error.", []interface{}{AllJSON{}}},
+	{"ashby applicants-by-week", "One entry per job (or per source with --by-source).", []interface{}{[]AshbyJobJSON{}}},
+	{"ashby funnel", "Applications reaching each stage. With --output jsonl, stages are streamed, or jobs with --by-job.", []interface{}{FunnelJSON{}}},
+	{"ashby offer-acceptance", "Offers extended and accepted per week.", []interface{}{OfferAcceptanceJSON{}}},
+	{"ashby offers-by-week", "One entry per job.", []interface{}{[]AshbyJobJSON{}}},
+	{"ashby rejection-reasons", "One entry per archive reason.", []interface{}{[]RejectionReasonJSON{}}},
+	{"datum active-users", "Distinct active users per week.", []interface{}{ActiveUsersJSON{}}},
+	{"datum resource-activity", "One entry per resource type.", []interface{}{[]ResourceActivityJSON{}}},
+	{"datum top-users", "The most active users, busiest first.", []interface{}{[]TopUserJSON{}}},
+	{"export json", "Each selected metric under its own key.", []interface{}{ExportJSON{}}},
+	{"github approvals", "Approvals per reviewer and week.", []interface{}{ApprovalsJSON{}}},
+	{"github ci", "Completed CI runs per week.", []interface{}{CIJSON{}}},
+	{"github downloads", "Release asset downloads.", []interface{}{DownloadsJSON{}}},
+	{"github issues-opened-vs-closed", "Issues opened and closed per week.", []interface{}{IssuesJSON{}}},
+	{"github lead-time", "Median pull request lead time per week.", []interface{}{LeadTimeJSON{}}},
+	{"github overview", "Repository, star, and open issue counts for an owner.", []interface{}{OverviewJSON{}}},
+	{"github prs", "Pull requests per state and week.", []interface{}{PRsJSON{}}},
+	{"github scorecard", "Health figures per repository.", []interface{}{ScorecardJSON{}}},
+	{"github stars", "Stars per repository. With --output jsonl, repositories are streamed.", []interface{}{StarsJSON{}}},
+	{"github stars --by-language", "Stars per primary language. With --output jsonl, languages are streamed.", []interface{}{StarsByLanguageJSON{}}},
+	{"incidents", "Incidents per label and week: one repository's document, or with several repositories each one's plus their combined counts. With --output jsonl, several repositories are streamed one per line.", []interface{}{IncidentsRepoJSON{}, IncidentsMultiJSON{}}},
+	{"incidents --mttr", "Mean time to resolution per week.", []interface{}{MTTRJSON{}}},
+	{"report", "One row per metric source that succeeded. With --output jsonl, rows are streamed.", []interface{}{ReportJSON{}}},
+	{"weeks", "The weeks reports cover.", []interface{}{WeeksJSON{}}},
+}
+
+func runDescribe(cmd *cobra.Command, args []string) error {
+	for _, arg := range args {
+		if arg == "-h" || arg == "--help" {
+			return cmd.Help()
+		}
+	}
+	if len(args) == 0 {
+		for _, d := range jsonSchemas {
+			fmt.Fprintf(stdout, "%-32s %s\n", d.command, d.description)
+		}
+		return nil
+	}
+
+	name := strings.Join(args, " ")
+	for _, d := range jsonSchemas {
+		if d.command == name {
+			return printJSON(d.schema())
+		}
+	}
+	return fmt.Errorf("no JSON output to describe for %q (run 'scorecard describe' for a list)", name)
+}
+
+// schema returns the JSON Schema of d's document.
+func (d describedOutput) schema() *jsonSchema {
+	var s *jsonSchema
+	if len(d.docs) == 1 {
+		s = schemaForType(reflect.TypeOf(d.docs[0]))
+	} else {
+		s = &jsonSchema{}
+		for _, doc := range d.docs {
+			s.OneOf = append(s.OneOf, schemaForType(reflect.TypeOf(doc)))
+		}
+	}
+	s.Schema = "https://json-schema.org/draft/2020-12/schema"
+	s.Title = "scorecard " + d.command
+	s.Description = d.description
+	return s
+}
+
+// jsonSchema is the subset of JSON Schema that describe emits.
+type jsonSchema struct {
+	Schema               string            `json:"$schema,omitempty"`
+	Title                string            `json:"title,omitempty"`
+	Description          string            `json:"description,omitempty"`
+	Type                 interface{}       `json:"type,omitempty"` // a name, or [name, "null"]
+	Format               string            `json:"format,omitempty"`
+	Properties           *schemaProperties `json:"properties,omitempty"`
+	Required             []string          `json:"required,omitempty"`
+	Items                *jsonSchema       `json:"items,omitempty"`
+	AdditionalProperties *jsonSchema       `json:"additionalProperties,omitempty"`
+	OneOf                []*jsonSchema     `json:"oneOf,omitempty"`
+}
+
+// schemaProperties are an object's properties, kept in struct field order
+// so the schema reads like the output.
+type schemaProperties struct {
+	names   []string
+	schemas map[string]*jsonSchema
+}
+
+func (p *schemaProperties) add(name string, s *jsonSchema) {
+	if p.schemas == nil {
+		p.schemas = make(map[string]*jsonSchema)
+	}
+	if _, ok := p.schemas[name]; !ok {
+		p.names = append(p.names, name)
+	}
+	p.schemas[name] = s
+}
+
+func (p *schemaProperties) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, name := range p.names {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		value, err := json.Marshal(p.schemas[name])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemaForType describes how encoding/json marshals values of type t.
+// Pointers may be null; fields without omitempty are required.
+func schemaForType(t reflect.Type) *jsonSchema {
+	nullable := false
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+
+	var s *jsonSchema
+	switch {
+	case t == timeType:
+		s = &jsonSchema{Type: "string", Format: "date-time"}
+	case t == rawMessageType || t.Kind() == reflect.Interface:
+		s = &jsonSchema{} // any JSON value
+	default:
+		switch t.Kind() {
+		case reflect.String:
+			s = &jsonSchema{Type: "string"}
+		case reflect.Bool:
+			s = &jsonSchema{Type: "boolean"}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			s = &jsonSchema{Type: "integer"}
+		case reflect.Float32, reflect.Float64:
+			s = &jsonSchema{Type: "number"}
+		case reflect.Slice, reflect.Array:
+			s = &jsonSchema{Type: "array", Items: schemaForType(t.Elem())}
+		case reflect.Map:
+			s = &jsonSchema{Type: "object", AdditionalProperties: schemaForType(t.Elem())}
+		case reflect.Struct:
+			s = &jsonSchema{Type: "object", Properties: &schemaProperties{}}
+			addStructFields(s, t)
+		default:
+			s = &jsonSchema{}
+		}
+	}
+
+	if nullable && s.Type != nil {
+		s.Type = []string{s.Type.(string), "null"}
+	}
+	return s
+}
+
+// addStructFields adds t's JSON fields to the object schema s, flattening
+// embedded structs as encoding/json does.
+func addStructFields(s *jsonSchema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			addStructFields(s, f.Type)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		s.Properties.add(name, schemaForType(f.Type))
+		if !strings.Contains(","+opts+",", ",omitempty,") {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var downloadsCmd = &cobra.Command{
+	Use:   "downloads [org]/[repo]",
+	Short: "Display release asset download counts for a repository",
+	Long: `Fetch all releases for a repository and sum the download counts of their
+assets, reporting a total per release and a grand total.
+
+GitHub only exposes cumulative download counts, so the numbers are totals since
+each asset was uploaded. Use --snapshot to record the current counts and
+--delta to show the change since the last recorded snapshot (e.g. run weekly
+to track weekly gains). Snapshots are kept separately from star snapshots.
+
+Requires GITHUB_TOKEN (or github.token in the config file) for API authentication.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDownloads,
+}
+
+func init() {
+	githubCmd.AddCommand(downloadsCmd)
+	downloadsCmd.Flags().Bool("json", false, "Output in JSON format")
+	downloadsCmd.Flags().Bool("raw", false, "Write unprocessed API responses to stdout instead of a table")
+	downloadsCmd.Flags().Bool("snapshot", false, "Record this run's download counts in the snapshot history")
+	downloadsCmd.Flags().Bool("delta", false, "Show per-release change since the last recorded snapshot")
+	downloadsCmd.Flags().String("snapshot-file", "", "Path to the download snapshot history (default: <user config dir>/scorecard/downloads.json)")
+}
+
+// downloads returns the sum of the release's asset download counts.
+func (r githubRelease) downloads() int {
+	total := 0
+	for _, asset := range r.Assets {
+		total += asset.DownloadCount
+	}
+	return total
+}
+
+func runDownloads(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	args, err := argsOrConfig(args, "github.repo", "an org/repo")
+	if err != nil {
+		return err
+	}
+	repo := args[0]
+	outputJSON := jsonOutput()
+	useDelta, _ := cmd.Flags().GetBool("delta")
+	recordSnapshot, _ := cmd.Flags().GetBool("snapshot")
+	snapshotFile, _ := cmd.Flags().GetString("snapshot-file")
+	outputRaw := enableRawOutput(cmd)
+
+	token := githubToken()
+	if token == "" {
+		return errNoGitHubToken
+	}
+
+	progressf("Fetching releases for %s...\n", repo)
+	releases, err := fetchReleases(ctx, token, repo)
+	if err != nil {
+		return fmt.Errorf("failed to fetch releases: %w", err)
+	}
+
+	if outputRaw {
+		return nil
+	}
+
+	total := 0
+	for _, r := range releases {
+		total += r.downloads()
+	}
+	now := timeNow().UTC()
+
+	// Compare against and/or record the snapshot history
+	var previous *starSnapshot
+	if useDelta || recordSnapshot {
+		if snapshotFile == "" {
+			snapshotFile, err = defaultSnapshotFile("downloads.json")
+			if err != nil {
+				return err
+			}
+		}
+		history, err := loadStarHistory(snapshotFile)
+		if err != nil {
+			return err
+		}
+		if snap, ok := history.latest(repo); ok {
+			previous = &snap
+		}
+		if recordSnapshot {
+			snap := starSnapshot{Timestamp: now, Repos: make(map[string]int), Total: total}
+			for _, r := range releases {
+				snap.Repos[r.TagName] = r.downloads()
+			}
+			history[repo] = append(history[repo], snap)
+			if err := saveStarHistory(snapshotFile, history); err != nil {
+				return err
+			}
+			progressf("Recorded snapshot in %s\n", snapshotFile)
+		}
+	}
+
+	if wantTemplateData() {
+		data := newTemplateData("github downloads", repo, nil, "")
+		for _, r := range releases {
+			data.addTotalRow(r.TagName, "", r.downloads())
+		}
+		if err := data.output(); err != nil || outputFormat == "template" {
+			return err
+		}
+	}
+
+	if outputJSON {
+		return printDownloadsJSON(repo, releases, total, now, previous, useDelta || recordSnapshot)
+	}
+
+	if len(releases) == 0 {
+		fmt.Fprintf(stdout, "No releases found for %s\n", repo)
+		return nil
+	}
+
+	if gridFormat() {
+		headers := []string{"Release", "Assets", "Downloads"}
+		if useDelta {
+			headers = append(headers, "Change")
+		}
+		var rows [][]string
+		for _, r := range releases {
+			row := []string{r.TagName, strconv.Itoa(len(r.Assets)), strconv.Itoa(r.downloads())}
+			if useDelta {
+				change := "n/a"
+				if previous != nil {
+					if prev, ok := previous.Repos[r.TagName]; ok {
+						change = fmt.Sprintf("%+d", r.downloads()-prev)
+					}
+				}
+				row = append(row, change)
+			}
+			rows = append(rows, row)
+		}
+		footer := []string{"Total [ " + now.Format("2006-01-02 15:04 UTC") + " ]", "", strconv.Itoa(total)}
+		if useDelta {
+			footer = append(footer, "")
+		}
+		printGrid(headers, append(rows, footer))
+		return nil
+	}
+
+	// Print header
+	width := 62
+	if useDelta {
+		width = 73
+		fmt.Fprintf(stdout, "%-40s %10s %10s %10s\n", "Release", "Assets", "Downloads", "Change")
+	} else {
+		fmt.Fprintf(stdout, "%-40s %10s %10s\n", "Release", "Assets", "Downloads")
+	}
+	fmt.Fprintln(stdout, strings.Repeat("=", width))
+
+	// Releases are listed newest first, as returned by the API
+	for _, r := range releases {
+		if !useDelta {
+			fmt.Fprintf(stdout, "%-40s %10d %10d\n", r.TagName, len(r.Assets), r.downloads())
+			continue
+		}
+		change := "n/a"
+		if previous != nil {
+			if prev, ok := previous.Repos[r.TagName]; ok {
+				change = fmt.Sprintf("%+d", r.downloads()-prev)
+			}
+		}
+		fmt.Fprintf(stdout, "%-40s %10d %10d %10s\n", r.TagName, len(r.Assets), r.downloads(), change)
+	}
+
+	// Print footer
+	fmt.Fprintln(stdout, strings.Repeat("=", width))
+	timestamp := now.Format("2006-01-02 15:04 UTC")
+	fmt.Fprintf(stdout, "%-51s %10d\n", fmt.Sprintf("Total [ %s ]", timestamp), total)
+
+	if useDelta || recordSnapshot {
+		if previous != nil {
+			since := previous.Timestamp.UTC().Format("2006-01-02 15:04 UTC")
+			fmt.Fprintf(stdout, "\nDownloads since %s: %+d\n", since, total-previous.Total)
+		} else {
+			fmt.Fprintf(stdout, "\nDownloads since last snapshot: n/a (no previous snapshot)\n")
+		}
+	}
+
+	return nil
+}
+
+// fetchReleases returns every release in repo, newest first, including
+// drafts and releases without assets.
+func fetchReleases(ctx context.Context, token, repo string) ([]githubRelease, error) {
+	var all []githubRelease
+	progress := newFetchProgress("releases")
+	defer progress.done()
+
+	client := newHTTPClient()
+
+	// Follow the Link header until there is no next page
+	next := fmt.Sprintf("https://api.github.com/repos/%s/releases?per_page=100", repo)
+	for next != "" {
+		body, nextURL, err := githubRequestPage(ctx, client, token, next)
+		if errors.Is(err, errGitHubNotFound) {
+			return nil, fmt.Errorf("repository not found: %s", repo)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var releases []githubRelease
+		if err := json.Unmarshal(body, &releases); err != nil {
+			return nil, err
+		}
+
+		writeRaw(body)
+		progress.page(len(releases))
+
+		all = append(all, releases...)
+		next = nextURL
+	}
+
+	return all, nil
+}
+
+func printDownloadsJSON(repo string, releases []githubRelease, total int, generated time.Time, previous *starSnapshot, withDelta bool) error {
+	output := DownloadsJSON{Repository: repo, GeneratedAt: generated, Releases: []DownloadsReleaseJSON{}, Total: total}
+	for _, r := range releases {
+		data := DownloadsReleaseJSON{Tag: r.TagName, Name: r.Name, PublishedAt: r.PublishedAt, Assets: []DownloadsAssetJSON{}, Downloads: r.downloads()}
+		for _, asset := range r.Assets {
+			data.Assets = append(data.Assets, DownloadsAssetJSON{Name: asset.Name, Downloads: asset.DownloadCount})
+		}
+		if previous != nil {
+			if prev, ok := previous.Repos[r.TagName]; ok {
+				change := data.Downloads - prev
+				data.Change = &change
+			}
+		}
+		output.Releases = append(output.Releases, data)
+	}
+
+	if withDelta {
+		output.Delta = &DownloadsDeltaJSON{}
+		if previous != nil {
+			change := total - previous.Total
+			output.Delta.PreviousTimestamp = &previous.Timestamp
+			output.Delta.PreviousTotal = &previous.Total
+			output.Delta.Change = &change
+		}
+	}
+
+	return printJSON(output)
+}
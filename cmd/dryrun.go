@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// dryRun holds the value of the persistent --dry-run flag. API requests and
+// datumctl invocations are logged to stderr instead of being made, and
+// return empty results. Nothing is read from or written to the caches,
+// snapshot files, --prometheus-file, or Slack.
+var dryRun bool
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print the API requests and datumctl commands that would run, without running them")
+}
+
+// dryRunf logs a request that --dry-run skipped.
+func dryRunf(format string, args ...interface{}) {
+	stderrf("[dry-run] "+format+"\n", args...)
+}
+
+// dryRunGitHubResponse returns an empty response body shaped like what the
+// GitHub endpoint at rawURL returns: an object for single resources, search,
+// and workflow runs, and an empty array for lists. Owners are reported as
+// organizations so fetchOwnerRepos plans the org endpoint.
+func dryRunGitHubResponse(rawURL string) []byte {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return []byte("[]")
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	switch {
+	case parts[0] == "users" && len(parts) == 2:
+		return []byte(`{"type":"Organization"}`)
+	case parts[0] == "repos" && len(parts) == 3:
+		return []byte("{}")
+	case parts[0] == "search", strings.HasSuffix(u.Path, "/actions/runs"):
+		return []byte(`{"total_count":0}`)
+	}
+	return []byte("[]")
+}
+
+// dryRunAshbyResponse is an empty, final page of any Ashby list endpoint.
+var dryRunAshbyResponse = []byte(`{"success":true,"results":[],"moreDataAvailable":false}`)
+
+// shellQuote joins args for display, quoting those a shell would split.
+func shellQuote(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\"'$\\|&;<>()*?") {
+			arg = strconv.Quote(arg)
+		}
+		quoted[i] = arg
+	}
+	return strings.Join(quoted, " ")
+}
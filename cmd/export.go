@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export metrics from several sources in machine-readable form",
+	Long:  "Commands for exporting all selected metrics as a single document.",
+}
+
+var exportJSONCmd = &cobra.Command{
+	Use:   "json",
+	Short: "Export all selected metrics as a single JSON document",
+	Long: `Run every selected metric and print one JSON object with each metric under a
+named key and a shared meta block:
+
+  {
+    "meta":         {"generated_at": ..., "window": {...}},
+    "github_stars": {...},   (--org)
+    "incidents":    {...},   (--repo)
+    "active_users": {...},   (--datum)
+    "errors":       {"incidents": "..."}
+  }
+
+A metric that fails to load is reported in the "errors" map instead of
+aborting the export. This is the machine-readable counterpart to 'scorecard report'.`,
+	RunE: runExportJSON,
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.AddCommand(exportJSONCmd)
+	addReportSourceFlags(exportJSONCmd)
+}
+
+func runExportJSON(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	sources, err := getReportSources(cmd)
+	if err != nil {
+		return err
+	}
+
+	weeks := getLastNWeeks(defaultWeekCount())
+	currentWeek := getCurrentWeekStart()
+
+	output := ExportJSON{
+		Meta: ExportMetaJSON{
+			GeneratedAt: timeNow().UTC(),
+			Window: ExportWindowJSON{
+				Start:       weeks[0],
+				End:         weekEndDate(weeks[len(weeks)-1]),
+				Weeks:       len(weeks),
+				CurrentWeek: currentWeek,
+			},
+		},
+	}
+	errs := make(map[string]string)
+
+	if sources.Org != "" {
+		if stars, err := exportStars(ctx, sources.Org); err != nil {
+			errs["github_stars"] = err.Error()
+		} else {
+			output.GitHubStars = stars
+		}
+	}
+
+	if sources.Repo != "" {
+		if incidents, err := exportIncidents(ctx, sources.Repo, weeks, currentWeek); err != nil {
+			errs["incidents"] = err.Error()
+		} else {
+			output.Incidents = incidents
+		}
+	}
+
+	if sources.Datum {
+		if activeUsers, err := exportActiveUsers(ctx, weeks, currentWeek); err != nil {
+			errs["active_users"] = err.Error()
+		} else {
+			output.ActiveUsers = activeUsers
+		}
+	}
+
+	if len(errs) > 0 {
+		output.Errors = errs
+	}
+
+	return printJSON(output)
+}
+
+func exportStars(ctx context.Context, owner string) (*ExportStarsJSON, error) {
+	token := githubToken()
+	if token == "" {
+		return nil, errNoGitHubToken
+	}
+
+	progressf("Fetching repositories for %s...\n", owner)
+	repos, err := fetchOwnerRepos(ctx, token, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &ExportStarsJSON{Owner: owner}
+	for _, repo := range repos {
+		data.Repositories = append(data.Repositories, ExportStarsRepoJSON{Repository: repo.Name, Stars: repo.StargazersCount})
+		data.Total += repo.StargazersCount
+	}
+	return data, nil
+}
+
+func exportIncidents(ctx context.Context, repo string, weeks []string, currentWeek string) (*ExportIncidentsJSON, error) {
+	token := githubToken()
+	if token == "" {
+		return nil, errNoGitHubToken
+	}
+
+	progressf("Fetching incidents for %s...\n", repo)
+	counts, currentCounts, err := countIncidentsByWeek(ctx, token, repo, defaultIncidentLabels, weeks, currentWeek, false)
+	if err != nil {
+		return nil, err
+	}
+
+	toWeekData := func(c weeklyIncidentCounts) ExportIncidentsWeekJSON {
+		return ExportIncidentsWeekJSON{
+			WeekEnding:     weekStartToEnd(c.WeekStart),
+			IncidentIssue:  c.Labels[":incident/issue"],
+			IncidentReport: c.Labels[":incident/report"],
+			Total:          c.total(),
+		}
+	}
+
+	data := &ExportIncidentsJSON{Repository: repo, CurrentWeek: toWeekData(currentCounts)}
+	for _, c := range counts {
+		data.Weeks = append(data.Weeks, toWeekData(c))
+	}
+	return data, nil
+}
+
+func exportActiveUsers(ctx context.Context, weeks []string, currentWeek string) (*ActiveUsersJSON, error) {
+	datumctl, err := findDatumctl()
+	if err != nil {
+		return nil, err
+	}
+
+	progressf("Querying Datum Cloud audit logs for the %s...\n", strings.ToLower(describeWeeks(weeks)))
+	weekCounts, totalUsers, err := countActiveUsersByWeek(ctx, datumctl, 0, weeks, currentWeek)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &ActiveUsersJSON{
+		CurrentWeek: ActiveUsersWeekJSON{WeekEnding: weekStartToEnd(currentWeek), ActiveUsers: weekCounts[currentWeek]},
+		TotalUsers:  totalUsers,
+	}
+	for _, week := range weeks {
+		data.Weeks = append(data.Weeks, ActiveUsersWeekJSON{WeekEnding: weekStartToEnd(week), ActiveUsers: weekCounts[week]})
+	}
+	return data, nil
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// readCacheFile returns the contents of path if it was written less than
+// maxAge ago.
+func readCacheFile(path string, maxAge time.Duration) ([]byte, bool) {
+	if path == "" {
+		return nil, false
+	}
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > maxAge {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// writeCacheFile saves data at path. Failures are ignored since caches are
+// only an optimization.
+func writeCacheFile(path string, data []byte) {
+	if path == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	os.WriteFile(path, data, 0o600)
+}
@@ -1,12 +1,14 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,131 +21,829 @@ var githubCmd = &cobra.Command{
 	Long:  "Commands for pulling metrics and data from GitHub.",
 }
 
+var overviewCmd = &cobra.Command{
+	Use:   "overview [org-or-user]",
+	Short: "Summarize repositories, stars, and open issues for a GitHub organization or user",
+	Long: `Detect whether the owner is a GitHub organization or user and print a one-line
+summary of its repository count, total stars, and total open issues.
+
+Open issue counts come from GitHub's open_issues_count, which includes open pull requests.
+
+Requires GITHUB_TOKEN (or github.token in the config file) for API authentication.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runOverview,
+}
+
 var starsCmd = &cobra.Command{
-	Use:   "stars [org-or-user]",
-	Short: "Display star counts for repositories in a GitHub organization or user",
+	Use:   "stars [org-or-user]...",
+	Short: "Display star counts for repositories in GitHub organizations or users",
 	Long: `Fetch and display star counts for all repositories in a GitHub organization or user.
 
-Requires GITHUB_TOKEN environment variable to be set for API authentication.
+Several owners may be given to combine them into one table; repositories are
+then shown as owner/repo and the total covers every owner. Snapshots for a
+combination of owners are recorded under the comma-separated owner list.
+
+Requires GITHUB_TOKEN (or github.token in the config file) for API authentication.
+
+By default, repositories are sorted by star count (ascending). Use --sort-by to
+sort by stars, name, forks, issues, or updated (last update time), and --desc to
+reverse the order. -s is shorthand for --sort-by=name.
+
+Use --top N to list only the N repositories ranked highest by the sort key; the
+rest are summed into an "(others)" row and the footer still totals every
+repository.
 
-By default, repositories are sorted by star count (ascending). Use -s to sort alphabetically.`,
-	Args: cobra.ExactArgs(1),
+Use --columns to choose which counts are shown, e.g. --columns stars,forks,issues.
+Available columns are stars, forks, watchers, and issues (GitHub's open issue
+count, which includes open pull requests). The footer totals each column.
+
+Use --exclude-forks and --exclude-archived to leave forked or archived
+repositories out of the table and totals; the number excluded is printed to
+stderr.
+
+Use --json for machine-readable output: a "repositories" array of
+{repository, stars} objects in the same order as the table, plus "total" and
+a "generated_at" timestamp. Extra --columns add forks, watchers, and
+open_issues fields to each repository and a "totals" object. With --top, an
+"others" object sums the repositories left out.
+
+Use --snapshot to record each run's counts in a local history file, and --delta
+to compare against the most recent snapshot. Whenever the history is used, a
+growth-rate footer (e.g. "+3.2%") is printed, or "n/a" on the first run.
+
+Use --append-csv FILE to maintain a spreadsheet-friendly history: each run adds
+a row with the timestamp, the total, and one column per repository. New
+repositories extend the header; earlier rows are padded so they remain valid.
+
+Use --by-language to summarize by primary language instead: the number of
+repositories and their total stars per language, most stars first.
+Repositories without a detected language are counted as "Unknown".`,
+	Args: cobra.ArbitraryArgs,
 	RunE: runStars,
 }
 
 func init() {
 	rootCmd.AddCommand(githubCmd)
 	githubCmd.AddCommand(starsCmd)
-	starsCmd.Flags().BoolP("sort", "s", false, "Sort alphabetically by repository name")
+	githubCmd.AddCommand(overviewCmd)
+	overviewCmd.Flags().Bool("json", false, "Output in JSON format")
+	starsCmd.Flags().BoolP("sort", "s", false, "Sort alphabetically by repository name (same as --sort-by=name)")
+	starsCmd.Flags().String("sort-by", "stars", "Sort by stars, name, forks, issues, or updated")
+	starsCmd.Flags().Int("top", 0, "Only list the N highest-ranked repositories by the sort key (0 = all)")
+	starsCmd.Flags().Bool("desc", false, "Reverse the sort order (largest or most recent first)")
+	starsCmd.Flags().Bool("raw", false, "Write unprocessed API responses to stdout instead of a table")
+	starsCmd.Flags().Bool("json", false, "Output in JSON format")
+	starsCmd.Flags().Bool("exclude-forks", false, "Leave forked repositories out of the report and total")
+	starsCmd.Flags().Bool("exclude-archived", false, "Leave archived repositories out of the report and total")
+	starsCmd.Flags().String("columns", "stars", "Comma-separated columns to show: stars, forks, watchers, issues")
+	starsCmd.Flags().Bool("snapshot", false, "Record this run's star counts in the snapshot history")
+	starsCmd.Flags().Bool("delta", false, "Show per-repo change and growth since the last recorded snapshot")
+	starsCmd.Flags().String("append-csv", "", "Append this run's star counts as a row to the given CSV file")
+	starsCmd.Flags().Bool("by-language", false, "Summarize repositories and stars by primary language")
+	starsCmd.Flags().String("snapshot-file", "", "Path to the star snapshot history (default: <user config dir>/scorecard/stars.json)")
 }
 
 type githubRepo struct {
-	Name            string `json:"name"`
-	StargazersCount int    `json:"stargazers_count"`
+	Name            string    `json:"name"`
+	FullName        string    `json:"full_name"`
+	StargazersCount int       `json:"stargazers_count"`
+	ForksCount      int       `json:"forks_count"`
+	WatchersCount   int       `json:"watchers_count"`
+	OpenIssuesCount int       `json:"open_issues_count"` // includes open pull requests
+	Language        string    `json:"language"`          // primary language; empty when GitHub detected none
+	Fork            bool      `json:"fork"`
+	Archived        bool      `json:"archived"`
+	PushedAt        time.Time `json:"pushed_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// starsColumn is a numeric column that --columns can add to the stars report.
+type starsColumn struct {
+	name   string // value accepted by --columns
+	header string
+	value  func(githubRepo) int
+}
+
+var starsColumns = []starsColumn{
+	{"stars", "Stars", func(r githubRepo) int { return r.StargazersCount }},
+	{"forks", "Forks", func(r githubRepo) int { return r.ForksCount }},
+	{"watchers", "Watchers", func(r githubRepo) int { return r.WatchersCount }},
+	{"issues", "Issues", func(r githubRepo) int { return r.OpenIssuesCount }},
+}
+
+// starsSortKeys maps each --sort-by value to an ascending comparator.
+var starsSortKeys = map[string]func(a, b githubRepo) bool{
+	"stars":   func(a, b githubRepo) bool { return a.StargazersCount < b.StargazersCount },
+	"name":    func(a, b githubRepo) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) },
+	"forks":   func(a, b githubRepo) bool { return a.ForksCount < b.ForksCount },
+	"issues":  func(a, b githubRepo) bool { return a.OpenIssuesCount < b.OpenIssuesCount },
+	"updated": func(a, b githubRepo) bool { return a.UpdatedAt.Before(b.UpdatedAt) },
+}
+
+// parseStarsColumns resolves a comma-separated --columns value, keeping the
+// order given.
+func parseStarsColumns(spec string) ([]starsColumn, error) {
+	var columns []starsColumn
+	var names []string
+	for _, c := range starsColumns {
+		names = append(names, c.name)
+	}
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		found := false
+		for _, c := range starsColumns {
+			if c.name == name {
+				columns = append(columns, c)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown column %q (must be one of %s)", name, strings.Join(names, ", "))
+		}
+	}
+	return columns, nil
+}
+
+type githubOwner struct {
+	Login string `json:"login"`
+	Type  string `json:"type"`
 }
 
 func runStars(cmd *cobra.Command, args []string) error {
-	target := args[0]
+	ctx := cmd.Context()
+	args, err := argsOrConfig(args, "github.org", "an org or user")
+	if err != nil {
+		return err
+	}
+	target := strings.Join(args, ",")
 	sortAlpha, _ := cmd.Flags().GetBool("sort")
+	sortBy, _ := cmd.Flags().GetString("sort-by")
+	sortDesc, _ := cmd.Flags().GetBool("desc")
+	top, _ := cmd.Flags().GetInt("top")
+	outputJSON := jsonOutput()
+	useDelta, _ := cmd.Flags().GetBool("delta")
+	recordSnapshot, _ := cmd.Flags().GetBool("snapshot")
+	snapshotFile, _ := cmd.Flags().GetString("snapshot-file")
+	appendCSV, _ := cmd.Flags().GetString("append-csv")
+	columnSpec, _ := cmd.Flags().GetString("columns")
+	excludeForks, _ := cmd.Flags().GetBool("exclude-forks")
+	excludeArchived, _ := cmd.Flags().GetBool("exclude-archived")
+	byLanguage, _ := cmd.Flags().GetBool("by-language")
+	outputRaw := enableRawOutput(cmd)
 
-	token := os.Getenv("GITHUB_TOKEN")
+	columns, err := parseStarsColumns(columnSpec)
+	if err != nil {
+		return err
+	}
+
+	// -s predates --sort-by and is kept as an alias for --sort-by=name
+	if sortAlpha {
+		if cmd.Flags().Changed("sort-by") && sortBy != "name" {
+			return fmt.Errorf("-s cannot be combined with --sort-by=%s", sortBy)
+		}
+		sortBy = "name"
+	}
+	less, ok := starsSortKeys[sortBy]
+	if !ok {
+		return fmt.Errorf("invalid --sort-by %q (must be stars, name, forks, issues, or updated)", sortBy)
+	}
+	if top < 0 {
+		return fmt.Errorf("--top must not be negative")
+	}
+	if byLanguage && outputFormat == "template" {
+		return fmt.Errorf("--by-language does not support --output template")
+	}
+
+	token := githubToken()
 	if token == "" {
-		return fmt.Errorf("GITHUB_TOKEN environment variable not set")
+		return errNoGitHubToken
 	}
 
-	fmt.Fprintf(os.Stderr, "Fetching repositories for %s...\n", target)
+	// Each owner independently falls back from orgs to users
+	var repos []githubRepo
+	for _, owner := range args {
+		progressf("Fetching repositories for %s...\n", owner)
 
-	// Try org endpoint first, then user
-	repos, err := fetchGitHubRepos(token, "orgs", target)
-	if err != nil {
-		repos, err = fetchGitHubRepos(token, "users", target)
+		ownerRepos, err := fetchOwnerRepos(ctx, token, owner)
 		if err != nil {
-			return fmt.Errorf("could not find organization or user '%s': %w", target, err)
+			return err
+		}
+		if len(args) > 1 {
+			for i := range ownerRepos {
+				ownerRepos[i].Name = ownerRepos[i].FullName
+			}
+		}
+		repos = append(repos, ownerRepos...)
+	}
+
+	if excludeForks || excludeArchived {
+		forks, archived := 0, 0
+		kept := repos[:0]
+		for _, repo := range repos {
+			switch {
+			case excludeForks && repo.Fork:
+				forks++
+			case excludeArchived && repo.Archived:
+				archived++
+			default:
+				kept = append(kept, repo)
+			}
+		}
+		repos = kept
+		if excludeForks {
+			progressf("Excluded %d forked repositories\n", forks)
+		}
+		if excludeArchived {
+			progressf("Excluded %d archived repositories\n", archived)
 		}
 	}
 
 	if len(repos) == 0 {
+		if dryRun {
+			return nil
+		}
 		return fmt.Errorf("no repositories found for '%s'", target)
 	}
 
-	// Sort repositories
-	if sortAlpha {
-		sort.Slice(repos, func(i, j int) bool {
-			return strings.ToLower(repos[i].Name) < strings.ToLower(repos[j].Name)
-		})
-	} else {
-		// Sort by star count ascending (most popular at the end)
-		sort.Slice(repos, func(i, j int) bool {
-			return repos[i].StargazersCount < repos[j].StargazersCount
-		})
+	if outputRaw {
+		return nil
 	}
 
-	// Print header
-	fmt.Printf("%-50s %10s\n", "Repository", "Stars")
-	fmt.Println(strings.Repeat("=", 62))
+	// Sort repositories, ascending unless --desc; ties keep API order
+	sort.SliceStable(repos, func(i, j int) bool {
+		if sortDesc {
+			return less(repos[j], repos[i])
+		}
+		return less(repos[i], repos[j])
+	})
 
-	// Print repos and calculate total
 	total := 0
 	for _, repo := range repos {
-		fmt.Printf("%-50s %10d\n", repo.Name, repo.StargazersCount)
 		total += repo.StargazersCount
 	}
+	now := timeNow().UTC()
+
+	// Compare against and/or record the snapshot history
+	var previous *starSnapshot
+	if useDelta || recordSnapshot {
+		if snapshotFile == "" {
+			var err error
+			snapshotFile, err = defaultSnapshotFile("stars.json")
+			if err != nil {
+				return err
+			}
+		}
+		history, err := loadStarHistory(snapshotFile)
+		if err != nil {
+			return err
+		}
+		if snap, ok := history.latest(target); ok {
+			previous = &snap
+		}
+		if recordSnapshot {
+			snap := starSnapshot{Timestamp: now, Repos: make(map[string]int), Total: total}
+			for _, repo := range repos {
+				snap.Repos[repo.Name] = repo.StargazersCount
+			}
+			history[target] = append(history[target], snap)
+			if err := saveStarHistory(snapshotFile, history); err != nil {
+				return err
+			}
+			progressf("Recorded snapshot in %s\n", snapshotFile)
+		}
+	}
+
+	if appendCSV != "" {
+		if err := appendStarsCSV(appendCSV, now, repos, total); err != nil {
+			return fmt.Errorf("failed to append to CSV: %w", err)
+		}
+		progressf("Appended star counts to %s\n", appendCSV)
+	}
+
+	if byLanguage {
+		return printStarsByLanguage(repos, total, now)
+	}
+
+	// Totals always cover every repository, even those folded into (others)
+	shown, others := splitTopStars(repos, top, sortDesc)
+	othersLabel := fmt.Sprintf("(others: %d repositories)", len(others))
+
+	if wantTemplateData() {
+		data := newTemplateData("github stars", target, nil, "")
+		for _, repo := range shown {
+			data.addTotalRow(repo.Name, "", repo.StargazersCount)
+		}
+		if len(others) > 0 {
+			data.addTotalRow(othersLabel, "", sumRepos(others, starsColumns[0]))
+		}
+		if err := data.output(); err != nil || outputFormat == "template" {
+			return err
+		}
+	}
 
-	// Print footer
-	fmt.Println(strings.Repeat("=", 62))
-	timestamp := time.Now().UTC().Format("2006-01-02 15:04 UTC")
-	fmt.Printf("%-50s %10d\n", fmt.Sprintf("Total [ %s ]", timestamp), total)
+	if outputJSON {
+		return printStarsJSON(target, shown, others, columns, total, now, previous, useDelta || recordSnapshot)
+	}
+
+	// Build the rows, then print them as text or through printGrid
+	headers := []string{"Repository"}
+	for _, c := range columns {
+		headers = append(headers, c.header)
+	}
+	if useDelta {
+		headers = append(headers, "Change", "Growth")
+	}
+	var rows [][]string
+	for _, repo := range shown {
+		row := []string{repo.Name}
+		for _, c := range columns {
+			row = append(row, strconv.Itoa(c.value(repo)))
+		}
+		if useDelta {
+			change, growth := "n/a", "n/a"
+			if previous != nil {
+				if prev, ok := previous.Repos[repo.Name]; ok {
+					change = fmt.Sprintf("%+d", repo.StargazersCount-prev)
+					growth = formatGrowth(growthRate(repo.StargazersCount, prev))
+				}
+			}
+			row = append(row, change, growth)
+		}
+		rows = append(rows, row)
+	}
+	// The (others) and total rows have no change columns
+	var summaries [][]string
+	if len(others) > 0 {
+		row := []string{othersLabel}
+		for _, c := range columns {
+			row = append(row, strconv.Itoa(sumRepos(others, c)))
+		}
+		summaries = append(summaries, row)
+	}
+	timestamp := now.Format("2006-01-02 15:04 UTC")
+	footer := []string{fmt.Sprintf("Total [ %s ]", timestamp)}
+	for _, c := range columns {
+		footer = append(footer, strconv.Itoa(sumRepos(repos, c)))
+	}
+
+	if gridFormat() {
+		for _, row := range append(summaries, footer) {
+			for len(row) < len(headers) {
+				row = append(row, "")
+			}
+			rows = append(rows, row)
+		}
+		printGrid(headers, rows)
+	} else {
+		printRow := func(row []string) {
+			fmt.Fprintf(stdout, "%-50s", row[0])
+			for _, cell := range row[1:] {
+				fmt.Fprintf(stdout, " %10s", cell)
+			}
+			fmt.Fprintln(stdout)
+		}
+		width := 51 + 11*(len(headers)-1)
+		printRow(headers)
+		fmt.Fprintln(stdout, strings.Repeat("=", width))
+		for _, row := range rows {
+			printRow(row)
+		}
+		for _, row := range summaries {
+			printRow(row)
+		}
+		fmt.Fprintln(stdout, strings.Repeat("=", width))
+		printRow(footer)
+	}
+
+	if useDelta || recordSnapshot {
+		if previous != nil {
+			since := previous.Timestamp.UTC().Format("2006-01-02 15:04 UTC")
+			printTableNote("\nGrowth since %s: %s (%+d stars)\n", since, formatGrowth(growthRate(total, previous.Total)), total-previous.Total)
+		} else {
+			printTableNote("\nGrowth: n/a (no previous snapshot)\n")
+		}
+	}
 
 	return nil
 }
 
-func fetchGitHubRepos(token, entityType, target string) ([]githubRepo, error) {
-	var allRepos []githubRepo
-	page := 1
+// languageStars is the star total of the repositories in one language.
+type languageStars struct {
+	Language string
+	Repos    int
+	Stars    int
+}
 
-	client := &http.Client{Timeout: 30 * time.Second}
+// starsByLanguage groups repos by primary language, most stars first and then
+// by name. Repositories without a language are grouped under "Unknown".
+func starsByLanguage(repos []githubRepo) []languageStars {
+	byName := make(map[string]*languageStars)
+	for _, repo := range repos {
+		name := repo.Language
+		if name == "" {
+			name = "Unknown"
+		}
+		l, ok := byName[name]
+		if !ok {
+			l = &languageStars{Language: name}
+			byName[name] = l
+		}
+		l.Repos++
+		l.Stars += repo.StargazersCount
+	}
 
-	for {
-		url := fmt.Sprintf("https://api.github.com/%s/%s/repos?per_page=100&page=%d", entityType, target, page)
+	languages := make([]languageStars, 0, len(byName))
+	for _, l := range byName {
+		languages = append(languages, *l)
+	}
+	sort.Slice(languages, func(i, j int) bool {
+		if languages[i].Stars != languages[j].Stars {
+			return languages[i].Stars > languages[j].Stars
+		}
+		return languages[i].Language < languages[j].Language
+	})
+	return languages
+}
+
+// printStarsByLanguage prints the --by-language summary of repos.
+func printStarsByLanguage(repos []githubRepo, total int, generated time.Time) error {
+	languages := starsByLanguage(repos)
 
-		req, err := http.NewRequest("GET", url, nil)
+	if jsonOutput() {
+		output := StarsByLanguageJSON{Total: total, GeneratedAt: generated}
+		for _, l := range languages {
+			data := StarsLanguageJSON{Language: l.Language, Repositories: l.Repos, Stars: l.Stars}
+			if total > 0 {
+				share := float64(l.Stars) / float64(total) * 100
+				data.SharePct = &share
+			}
+			output.Languages = append(output.Languages, data)
+		}
+		return printJSONList(output.Languages, output)
+	}
+
+	share := func(stars int) string {
+		if total == 0 {
+			return "n/a"
+		}
+		return fmt.Sprintf("%.1f%%", float64(stars)/float64(total)*100)
+	}
+	headers := []string{"Language", "Repos", "Stars", "Share"}
+	var rows [][]string
+	for _, l := range languages {
+		rows = append(rows, []string{l.Language, strconv.Itoa(l.Repos), strconv.Itoa(l.Stars), share(l.Stars)})
+	}
+	footer := []string{fmt.Sprintf("Total [ %s ]", generated.Format("2006-01-02 15:04 UTC")), strconv.Itoa(len(repos)), strconv.Itoa(total), ""}
+
+	if gridFormat() {
+		printGrid(headers, append(rows, footer))
+		return nil
+	}
+	printRow := func(row []string) {
+		fmt.Fprintf(stdout, "%-30s", row[0])
+		for _, cell := range row[1:] {
+			fmt.Fprintf(stdout, " %10s", cell)
+		}
+		fmt.Fprintln(stdout)
+	}
+	width := 31 + 11*(len(headers)-1)
+	printRow(headers)
+	fmt.Fprintln(stdout, strings.Repeat("=", width))
+	for _, row := range rows {
+		printRow(row)
+	}
+	fmt.Fprintln(stdout, strings.Repeat("=", width))
+	printRow(footer)
+	return nil
+}
+
+// splitTopStars keeps the n repositories ranked highest by the sort key,
+// preserving display order, and returns the rest as others. With an ascending
+// sort the highest-ranked repositories are at the end. n == 0 keeps all.
+func splitTopStars(repos []githubRepo, n int, desc bool) (top, others []githubRepo) {
+	if n == 0 || n >= len(repos) {
+		return repos, nil
+	}
+	if desc {
+		return repos[:n], repos[n:]
+	}
+	return repos[len(repos)-n:], repos[:len(repos)-n]
+}
+
+// sumRepos totals one column over repos.
+func sumRepos(repos []githubRepo, c starsColumn) int {
+	sum := 0
+	for _, repo := range repos {
+		sum += c.value(repo)
+	}
+	return sum
+}
+
+func printStarsJSON(target string, repos, others []githubRepo, columns []starsColumn, total int, generated time.Time, previous *starSnapshot, withGrowth bool) error {
+	output := StarsJSON{Target: target, GeneratedAt: generated, Total: total}
+	for _, repo := range repos {
+		data := StarsRepoJSON{Repository: repo.Name, Stars: repo.StargazersCount}
+		for _, c := range columns {
+			v := c.value(repo)
+			switch c.name {
+			case "forks":
+				data.Forks = &v
+			case "watchers":
+				data.Watchers = &v
+			case "issues":
+				data.OpenIssues = &v
+			}
+		}
+		if previous != nil {
+			if prev, ok := previous.Repos[repo.Name]; ok {
+				change := repo.StargazersCount - prev
+				data.Change = &change
+				if rate, ok := growthRate(repo.StargazersCount, prev); ok {
+					data.GrowthPct = &rate
+				}
+			}
+		}
+		output.Repositories = append(output.Repositories, data)
+	}
+
+	if len(others) > 0 {
+		output.Others = &StarsOthersJSON{Count: len(others), Stars: sumRepos(others, starsColumns[0])}
+	}
+	for _, c := range columns {
+		if c.name == "stars" {
+			continue
+		}
+		if output.Totals == nil {
+			output.Totals = make(map[string]int)
+		}
+		output.Totals[c.name] = sumRepos(repos, c) + sumRepos(others, c)
+		if output.Others != nil {
+			v := sumRepos(others, c)
+			switch c.name {
+			case "forks":
+				output.Others.Forks = &v
+			case "watchers":
+				output.Others.Watchers = &v
+			case "issues":
+				output.Others.OpenIssues = &v
+			}
+		}
+	}
+
+	if withGrowth {
+		output.Growth = &StarsGrowthJSON{}
+		if previous != nil {
+			output.Growth.PreviousTimestamp = &previous.Timestamp
+			output.Growth.PreviousTotal = &previous.Total
+			if rate, ok := growthRate(total, previous.Total); ok {
+				output.Growth.GrowthPct = &rate
+			}
+		}
+	}
+
+	return printJSONList(output.Repositories, output)
+}
+
+func runOverview(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	args, err := argsOrConfig(args, "github.org", "an org or user")
+	if err != nil {
+		return err
+	}
+	owner := args[0]
+	outputJSON := jsonOutput()
+
+	token := githubToken()
+	if token == "" {
+		return errNoGitHubToken
+	}
+
+	progressf("Looking up %s...\n", owner)
+	ownerType, err := fetchGitHubOwnerType(ctx, token, owner)
+	if err != nil {
+		return err
+	}
+
+	entityType := "users"
+	if ownerType == "Organization" {
+		entityType = "orgs"
+	}
+
+	progressf("Fetching repositories for %s...\n", owner)
+	repos, err := fetchGitHubRepos(ctx, token, entityType, owner)
+	if err != nil {
+		return fmt.Errorf("failed to fetch repositories for '%s': %w", owner, err)
+	}
+
+	stars, openIssues := 0, 0
+	for _, repo := range repos {
+		stars += repo.StargazersCount
+		openIssues += repo.OpenIssuesCount
+	}
+
+	if outputJSON {
+		return printJSON(OverviewJSON{
+			Owner:        owner,
+			Type:         ownerType,
+			Repositories: len(repos),
+			Stars:        stars,
+			OpenIssues:   openIssues,
+		})
+	}
+
+	if gridFormat() {
+		printGrid([]string{"Owner", "Type", "Repositories", "Stars", "Open Issues"},
+			[][]string{{owner, ownerType, strconv.Itoa(len(repos)), strconv.Itoa(stars), strconv.Itoa(openIssues)}})
+		return nil
+	}
+
+	fmt.Fprintf(stdout, "%-15s %s (%s)\n", "Owner:", owner, ownerType)
+	fmt.Fprintf(stdout, "%-15s %d\n", "Repositories:", len(repos))
+	fmt.Fprintf(stdout, "%-15s %d\n", "Stars:", stars)
+	fmt.Fprintf(stdout, "%-15s %d\n", "Open Issues:", openIssues)
+
+	return nil
+}
+
+// fetchGitHubOwnerType returns "Organization" or "User" for a GitHub account.
+func fetchGitHubOwnerType(ctx context.Context, token, owner string) (string, error) {
+	client := newHTTPClient()
+	body, err := githubRequest(ctx, client, token, fmt.Sprintf("https://api.github.com/users/%s", owner))
+	if errors.Is(err, errGitHubNotFound) {
+		return "", fmt.Errorf("could not find organization or user '%s'", owner)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var account githubOwner
+	if err := json.Unmarshal(body, &account); err != nil {
+		return "", err
+	}
+	return account.Type, nil
+}
+
+// fetchOwnerRepos fetches repositories for a GitHub owner, trying the org
+// endpoint first and falling back to the user endpoint.
+func fetchOwnerRepos(ctx context.Context, token, owner string) ([]githubRepo, error) {
+	repos, err := fetchGitHubRepos(ctx, token, "orgs", owner)
+	if err != nil {
+		repos, err = fetchGitHubRepos(ctx, token, "users", owner)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("could not find organization or user '%s': %w", owner, err)
 		}
+	}
+	return repos, nil
+}
+
+// errGitHubNotFound is returned by githubRequest when the API responds with 404.
+var errGitHubNotFound = errors.New("not found")
+
+// errGitHubRateLimited is returned when a request is still rate limited after
+// all retries.
+var errGitHubRateLimited = errors.New("rate limited")
 
-		req.Header.Set("Authorization", "Bearer "+token)
-		req.Header.Set("Accept", "application/vnd.github+json")
-		req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+// githubRequest performs an authenticated GET against the GitHub API and
+// returns the response body.
+func githubRequest(ctx context.Context, client *http.Client, token, url string) ([]byte, error) {
+	body, _, err := githubRequestPage(ctx, client, token, url)
+	return body, err
+}
+
+// githubRequestPage is like githubRequest but also returns the URL of the
+// next page from the Link header, or "" on the last page. Rate-limited
+// requests are retried up to --max-retries times; see githubRateLimitDelay.
+func githubRequestPage(ctx context.Context, client *http.Client, token, url string) ([]byte, string, error) {
+	return githubRequestRetries(ctx, client, token, url, maxRetries)
+}
 
-		resp, err := client.Do(req)
+// githubRequestRetries is githubRequestPage with an explicit retry limit, for
+// callers that would rather fall back than wait out a rate limit.
+func githubRequestRetries(ctx context.Context, client *http.Client, token, url string, retries int) ([]byte, string, error) {
+	if dryRun {
+		dryRunf("GET %s", url)
+		return dryRunGitHubResponse(url), "", nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	var resp *http.Response
+	var body []byte
+	for attempt := 0; ; attempt++ {
+		start := time.Now()
+		resp, err = client.Do(req)
 		if err != nil {
-			return nil, err
+			return nil, "", err
+		}
+
+		body, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, "", err
+		}
+		logger.Debug("github request", "url", url, "status", resp.StatusCode, "bytes", len(body), "ratelimit_remaining", resp.Header.Get("X-RateLimit-Remaining"), "elapsed", time.Since(start).Round(time.Millisecond))
+
+		delay, limited := githubRateLimitDelay(resp, attempt)
+		if !limited {
+			break
+		}
+		if attempt == retries {
+			return nil, "", fmt.Errorf("%w: API error %d: %s", errGitHubRateLimited, resp.StatusCode, string(body))
 		}
+		stderrf("Rate limited by GitHub; retrying in %s (%d/%d)\n", delay.Round(time.Second), attempt+1, retries)
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if resp.StatusCode == 404 {
+		return nil, "", errGitHubNotFound
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, "", fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+	}
+
+	return body, nextPageURL(resp.Header.Get("Link")), nil
+}
 
-		if resp.StatusCode == 404 {
-			resp.Body.Close()
-			return nil, fmt.Errorf("not found")
+// githubRateLimitDelay reports whether resp is a rate-limit rejection and, if
+// so, how long to wait before retrying. GitHub signals its primary limit with
+// a 403 or 429 and X-RateLimit-Remaining: 0, in which case the wait lasts
+// until X-RateLimit-Reset; secondary limits send Retry-After instead.
+func githubRateLimitDelay(resp *http.Response, attempt int) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	if resp.Header.Get("Retry-After") != "" {
+		return retryDelay(resp, attempt), true
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			// Allow a second of clock skew past the reset time
+			if d := time.Until(time.Unix(reset, 0)); d > 0 {
+				return d + time.Second, true
+			}
+			return time.Second, true
 		}
+		return retryDelay(resp, attempt), true
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return retryDelay(resp, attempt), true
+	}
+	// A plain 403 is a permissions problem, not a rate limit
+	return 0, false
+}
 
-		if resp.StatusCode != 200 {
-			body, _ := io.ReadAll(resp.Body)
-			resp.Body.Close()
-			return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+// nextPageURL extracts the rel="next" URL from a GitHub Link header, e.g.
+// <https://api.github.com/...&page=2>; rel="next", <...>; rel="last".
+func nextPageURL(link string) string {
+	for _, part := range strings.Split(link, ",") {
+		fields := strings.Split(part, ";")
+		target := strings.TrimSpace(fields[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+		for _, param := range fields[1:] {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return strings.Trim(target, "<>")
+			}
 		}
+	}
+	return ""
+}
 
-		var repos []githubRepo
-		if err := json.NewDecoder(resp.Body).Decode(&repos); err != nil {
-			resp.Body.Close()
+func fetchGitHubRepos(ctx context.Context, token, entityType, target string) ([]githubRepo, error) {
+	var allRepos []githubRepo
+	progress := newFetchProgress("repositories")
+	defer progress.done()
+
+	client := newHTTPClient()
+
+	// Follow the Link header until there is no next page
+	url := fmt.Sprintf("https://api.github.com/%s/%s/repos?per_page=100", entityType, target)
+	for url != "" {
+		body, next, err := githubRequestPage(ctx, client, token, url)
+		if err != nil {
 			return nil, err
 		}
-		resp.Body.Close()
 
-		if len(repos) == 0 {
-			break
+		var repos []githubRepo
+		if err := json.Unmarshal(body, &repos); err != nil {
+			return nil, err
 		}
+		writeRaw(body)
 
 		allRepos = append(allRepos, repos...)
-		page++
+		progress.page(len(repos))
+		url = next
 	}
 
 	return allRepos, nil
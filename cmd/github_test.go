@@ -0,0 +1,46 @@
+package cmd
+
+import "testing"
+
+func TestNextPageURL(t *testing.T) {
+	tests := []struct {
+		name string
+		link string
+		want string
+	}{
+		{"empty header", "", ""},
+		{
+			"next and last",
+			`<https://api.github.com/repositories/1/issues?per_page=100&page=2>; rel="next", <https://api.github.com/repositories/1/issues?per_page=100&page=9>; rel="last"`,
+			"https://api.github.com/repositories/1/issues?per_page=100&page=2",
+		},
+		{
+			"next listed after prev",
+			`<https://api.github.com/x?page=1>; rel="prev", <https://api.github.com/x?page=3>; rel="next"`,
+			"https://api.github.com/x?page=3",
+		},
+		{
+			"last page",
+			`<https://api.github.com/x?page=1>; rel="first", <https://api.github.com/x?page=8>; rel="prev"`,
+			"",
+		},
+		{
+			"extra params",
+			`<https://api.github.com/x?page=2>; type="application/json"; rel="next"`,
+			"https://api.github.com/x?page=2",
+		},
+		{
+			"encoded query, no space before rel",
+			`<https://api.github.com/x?labels=a%2Cb&page=2>;rel="next"`,
+			"https://api.github.com/x?labels=a%2Cb&page=2",
+		},
+		{"malformed target", `https://api.github.com/x?page=2; rel="next"`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextPageURL(tt.link); got != tt.want {
+				t.Errorf("nextPageURL(%q) = %q, want %q", tt.link, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,307 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	// rateLimit is the maximum number of requests per second sent to any one
+	// host, shared by every caller in the process. Zero disables rate limiting.
+	rateLimit float64
+
+	// concurrency is the maximum number of requests in flight at once across
+	// all hosts, and the default parallelism for commands that fetch concurrently.
+	concurrency int
+
+	// maxRetries is how many times a rate-limited or failed request is
+	// retried before the error is returned.
+	maxRetries int
+
+	// httpTimeout bounds each attempt of a request, including reading the
+	// response body.
+	httpTimeout time.Duration
+)
+
+func init() {
+	rootCmd.PersistentFlags().Float64Var(&rateLimit, "rate-limit", 0, "Maximum API requests per second per host (0 = unlimited)")
+	rootCmd.PersistentFlags().IntVar(&concurrency, "concurrency", 4, "Maximum concurrent API requests")
+	rootCmd.PersistentFlags().IntVar(&maxRetries, "max-retries", 5, "Maximum retries of a failed or rate-limited API request")
+	rootCmd.PersistentFlags().DurationVar(&httpTimeout, "http-timeout", 30*time.Second, "Timeout for each API request attempt")
+}
+
+// maxRetriesLimit bounds --max-retries; with backoff capped at maxRetryDelay,
+// more retries than this would keep a single request waiting for many minutes.
+const maxRetriesLimit = 10
+
+// validateHTTPFlags checks the values of the global HTTP flags.
+func validateHTTPFlags() error {
+	if rateLimit < 0 {
+		return fmt.Errorf("--rate-limit must not be negative")
+	}
+	if concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+	if maxRetries < 0 || maxRetries > maxRetriesLimit {
+		return fmt.Errorf("--max-retries must be between 0 and %d", maxRetriesLimit)
+	}
+	if httpTimeout <= 0 {
+		return fmt.Errorf("--http-timeout must be positive")
+	}
+	return nil
+}
+
+var (
+	sharedTransport     *limitedTransport
+	sharedTransportOnce sync.Once
+)
+
+// newHTTPClient returns an HTTP client for API calls. All clients share one
+// transport so that --rate-limit and --concurrency apply across every command
+// and goroutine in the process. Network errors and 5xx responses are retried
+// by retryTransport; rate limits are left to each API's caller, since GitHub
+// and Ashby signal them differently.
+func newHTTPClient() *http.Client {
+	// No client Timeout: it would span every retry, so retryTransport
+	// applies --http-timeout to each attempt instead
+	return &http.Client{Transport: &retryTransport{base: limitedSharedTransport()}}
+}
+
+// newSingleAttemptHTTPClient returns a client that sends each request once,
+// for requests that must not be repeated, such as posting a message: a
+// retried POST whose first attempt was slow rather than lost would post
+// twice. It shares the --rate-limit and --concurrency limits, and
+// --http-timeout bounds the request.
+func newSingleAttemptHTTPClient() *http.Client {
+	return &http.Client{Transport: limitedSharedTransport(), Timeout: httpTimeout}
+}
+
+// limitedSharedTransport returns the process-wide limitedTransport.
+func limitedSharedTransport() *limitedTransport {
+	sharedTransportOnce.Do(func() {
+		sharedTransport = &limitedTransport{
+			base:     http.DefaultTransport,
+			rate:     rateLimit,
+			inFlight: make(chan struct{}, concurrency),
+			buckets:  make(map[string]*tokenBucket),
+		}
+	})
+	return sharedTransport
+}
+
+// retryTransport is a RoundTripper that retries requests failing with a
+// network error or a transient server error (500, 502, 503, 504), up to
+// --max-retries times. It backs off exponentially from one second and honors
+// Retry-After. Each attempt is bounded by --http-timeout.
+type retryTransport struct {
+	base http.RoundTripper
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A request body can only be replayed if it can be re-created
+	replayable := req.Body == nil || req.GetBody != nil
+
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := context.WithTimeout(req.Context(), httpTimeout)
+		r := req.Clone(ctx)
+		if attempt > 0 && req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+			r.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(r)
+		retry := replayable && attempt < maxRetries && req.Context().Err() == nil
+
+		var delay time.Duration
+		switch {
+		case err != nil:
+			cancel()
+			if !retry {
+				return nil, err
+			}
+			delay = time.Second << attempt
+			stderrf("Request to %s failed (%v); retrying in %s (%d/%d)\n", req.URL.Host, err, delay, attempt+1, maxRetries)
+		case retry && transientStatus(resp.StatusCode):
+			delay = retryDelay(resp, attempt)
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			cancel()
+			stderrf("Request to %s failed (%s); retrying in %s (%d/%d)\n", req.URL.Host, resp.Status, delay, attempt+1, maxRetries)
+		default:
+			// The attempt's timeout must outlive RoundTrip until the caller
+			// has read the body
+			resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+			return resp, nil
+		}
+
+		if err := sleepContext(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// transientStatus reports whether an HTTP status is a server error worth
+// retrying.
+func transientStatus(code int) bool {
+	switch code {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// cancelOnClose releases a request attempt's context once its response body
+// is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// limitedTransport is a RoundTripper that caps concurrent requests and applies
+// a per-host token-bucket rate limit.
+type limitedTransport struct {
+	base     http.RoundTripper
+	rate     float64
+	inFlight chan struct{}
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.rate > 0 {
+		if err := t.bucket(req.URL.Host).wait(req); err != nil {
+			return nil, err
+		}
+	}
+
+	select {
+	case t.inFlight <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	defer func() { <-t.inFlight }()
+
+	return t.base.RoundTrip(req)
+}
+
+// bucket returns the token bucket for host, creating it on first use.
+func (t *limitedTransport) bucket(host string) *tokenBucket {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	b, ok := t.buckets[host]
+	if !ok {
+		b = newTokenBucket(t.rate)
+		t.buckets[host] = b
+	}
+	return b
+}
+
+// tokenBucket allows rate requests per second on average, with bursts of up
+// to max(1, rate) requests.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64) *tokenBucket {
+	burst := rate
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{rate: rate, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// wait blocks until a token is available or the request's context is done.
+func (b *tokenBucket) wait(req *http.Request) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		select {
+		case <-time.After(delay):
+		case <-req.Context().Done():
+			return req.Context().Err()
+		}
+	}
+}
+
+// maxRetryDelay caps the wait before any one retry, whether it comes from a
+// Retry-After header or from backoff.
+const maxRetryDelay = time.Minute
+
+// retryDelay returns how long to wait before retrying a rate-limited request.
+// It honors a Retry-After header given in seconds or as an HTTP date, and
+// otherwise backs off exponentially from one second (1s, 2s, 4s, ...), with
+// jitter so that concurrent requests don't retry in lockstep. Either way the
+// wait is capped at maxRetryDelay.
+func retryDelay(resp *http.Response, attempt int) time.Duration {
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+			if secs > int(maxRetryDelay/time.Second) {
+				return maxRetryDelay
+			}
+			return time.Duration(secs) * time.Second
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			d := time.Until(t)
+			if d < 0 {
+				return 0
+			}
+			if d > maxRetryDelay {
+				return maxRetryDelay
+			}
+			return d
+		}
+	}
+	d := maxRetryDelay
+	if attempt < 6 { // 1s << 6 already exceeds the cap
+		d = time.Second << attempt
+	}
+	// Wait between half and all of the backoff
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// sleepContext waits for d, returning early with the context's error if it is
+// canceled first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// setHTTPFlags pins --max-retries and --http-timeout for one test.
+func setHTTPFlags(t *testing.T, retries int, timeout time.Duration) {
+	t.Helper()
+	prevRetries, prevTimeout := maxRetries, httpTimeout
+	t.Cleanup(func() { maxRetries, httpTimeout = prevRetries, prevTimeout })
+	maxRetries, httpTimeout = retries, timeout
+}
+
+// retryClient returns a client that retries through retryTransport without
+// the process-wide limits.
+func retryClient() *http.Client {
+	return &http.Client{Transport: &retryTransport{base: http.DefaultTransport}}
+}
+
+// statusSequence serves the given statuses in turn, then 200, recording each
+// request body. Retry-After: 0 keeps the retries immediate.
+func statusSequence(statuses ...int) (*httptest.Server, *[]string) {
+	var mu sync.Mutex
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		n := len(bodies)
+		bodies = append(bodies, string(body))
+		mu.Unlock()
+		if n < len(statuses) {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(statuses[n])
+			return
+		}
+		io.WriteString(w, "ok")
+	}))
+	return srv, &bodies
+}
+
+func TestRetryTransportRetriesServerErrors(t *testing.T) {
+	setHTTPFlags(t, 3, 5*time.Second)
+	srv, bodies := statusSequence(http.StatusServiceUnavailable, http.StatusBadGateway)
+	defer srv.Close()
+
+	resp, err := retryClient().Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(body) != "ok" {
+		t.Errorf("got %d %q, want 200 \"ok\"", resp.StatusCode, body)
+	}
+	if len(*bodies) != 3 {
+		t.Errorf("server saw %d attempts, want 3", len(*bodies))
+	}
+}
+
+func TestRetryTransportGivesUpAfterMaxRetries(t *testing.T) {
+	setHTTPFlags(t, 1, 5*time.Second)
+	srv, bodies := statusSequence(http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError)
+	defer srv.Close()
+
+	resp, err := retryClient().Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", resp.StatusCode)
+	}
+	if len(*bodies) != 2 {
+		t.Errorf("server saw %d attempts, want 2", len(*bodies))
+	}
+}
+
+func TestRetryTransportDoesNotRetryClientErrors(t *testing.T) {
+	setHTTPFlags(t, 3, 5*time.Second)
+	srv, bodies := statusSequence(http.StatusNotFound)
+	defer srv.Close()
+
+	resp, err := retryClient().Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound || len(*bodies) != 1 {
+		t.Errorf("got %d after %d attempts, want 404 after 1", resp.StatusCode, len(*bodies))
+	}
+}
+
+func TestRetryTransportReplaysBody(t *testing.T) {
+	setHTTPFlags(t, 3, 5*time.Second)
+	srv, bodies := statusSequence(http.StatusBadGateway)
+	defer srv.Close()
+
+	// bytes.Reader bodies get a GetBody, so they can be replayed
+	req, _ := http.NewRequest(http.MethodPost, srv.URL, bytes.NewReader([]byte(`{"page":1}`)))
+	resp, err := retryClient().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if len(*bodies) != 2 || (*bodies)[0] != `{"page":1}` || (*bodies)[1] != `{"page":1}` {
+		t.Errorf("server saw bodies %q, want the same body twice", *bodies)
+	}
+}
+
+func TestRetryTransportKeepsUnreplayableBody(t *testing.T) {
+	setHTTPFlags(t, 3, 5*time.Second)
+	srv, bodies := statusSequence(http.StatusBadGateway)
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, srv.URL, io.NopCloser(strings.NewReader("once")))
+	resp, err := retryClient().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadGateway || len(*bodies) != 1 {
+		t.Errorf("got %d after %d attempts, want 502 after 1", resp.StatusCode, len(*bodies))
+	}
+}
+
+func TestRetryTransportTimesOutEachAttempt(t *testing.T) {
+	setHTTPFlags(t, 0, 50*time.Millisecond)
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	start := time.Now()
+	_, err := retryClient().Get(srv.URL)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want a deadline exceeded error", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("request took %s, want about --http-timeout", elapsed)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	header := func(v string) *http.Response {
+		resp := &http.Response{Header: make(http.Header)}
+		if v != "" {
+			resp.Header.Set("Retry-After", v)
+		}
+		return resp
+	}
+	tests := []struct {
+		name    string
+		resp    *http.Response
+		attempt int
+		min     time.Duration
+		max     time.Duration
+	}{
+		{"seconds", header("7"), 0, 7 * time.Second, 7 * time.Second},
+		{"zero", header("0"), 3, 0, 0},
+		{"seconds past the cap", header("86400"), 0, maxRetryDelay, maxRetryDelay},
+		{"http date", header(time.Now().Add(30 * time.Second).UTC().Format(http.TimeFormat)), 0, 28 * time.Second, 30 * time.Second},
+		{"http date past the cap", header(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)), 0, maxRetryDelay, maxRetryDelay},
+		{"past date", header(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)), 0, 0, 0},
+		{"no header, first attempt", header(""), 0, 500 * time.Millisecond, time.Second},
+		{"no header, third attempt", header(""), 2, 2 * time.Second, 4 * time.Second},
+		{"no header, capped", header(""), 40, maxRetryDelay / 2, maxRetryDelay},
+		{"unparseable", header("soon"), 1, time.Second, 2 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryDelay(tt.resp, tt.attempt); got < tt.min || got > tt.max {
+				t.Errorf("retryDelay() = %s, want between %s and %s", got, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestLimitedTransportCapsInFlight(t *testing.T) {
+	const limit, requests = 2, 6
+	var current, peak int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&current, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&current, -1)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: &limitedTransport{
+		base:     http.DefaultTransport,
+		inFlight: make(chan struct{}, limit),
+		buckets:  make(map[string]*tokenBucket),
+	}}
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(srv.URL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+	if peak > limit {
+		t.Errorf("%d requests in flight at once, want at most %d", peak, limit)
+	}
+}
+
+func TestTokenBucketWaitsPastBurst(t *testing.T) {
+	b := newTokenBucket(20) // burst of 20, then one token every 50ms
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+
+	start := time.Now()
+	for i := 0; i < 20; i++ {
+		if err := b.wait(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Errorf("burst took %s, want no wait", elapsed)
+	}
+	if err := b.wait(req); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("request past the burst waited %s, want about 50ms", elapsed)
+	}
+}
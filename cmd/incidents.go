@@ -1,246 +1,704 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
-	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 )
 
 var incidentsCmd = &cobra.Command{
-	Use:   "incidents [org]/[repo]",
-	Short: "Display incident counts by week for a GitHub repository",
+	Use:   "incidents [org]/[repo]...",
+	Short: "Display incident counts by week for GitHub repositories",
 	Long: `Query GitHub issues for a repository and count incidents by week.
 
-Looks for issues with the following labels:
+Looks for issues with the following labels by default:
   - :incident/issue
   - :incident/report
 
-Displays counts for the last 4 weeks.
-
-Requires GITHUB_TOKEN environment variable to be set for API authentication.`,
-	Args: cobra.ExactArgs(1),
+Several repositories may be given to report on them together. They are
+fetched concurrently (see --concurrency), the table then has one row per
+repository plus a combined total, and --per-label splits each repository's
+row by label. A repository that cannot be fetched is reported on stderr and
+the others are still shown, but the command exits non-zero. JSON output lists
+each repository under "repositories" and the totals under "combined".
+
+Use --label (repeatable) to count a different set of labels instead; the
+table then has one row per label plus a total. JSON output keys the weekly
+counts by label name under "labels".
+
+Displays counts for the last 4 weeks by default; use --weeks N to show more
+(up to 52), e.g. --weeks 13 for a quarter. Use --since and --until to choose a
+different range of completed weeks, using dates (2006-01-02) or relative
+references such as now-8w, last-week, or this-week.
+
+Counts for completed weeks are cached, so repeated runs only fetch the current
+week; use --refresh to refetch everything.
+
+Use --warn-threshold and --crit-threshold to color the weekly totals yellow or
+red when they exceed the given values. JSON output then includes a per-week
+status of ok, warn, or crit, judged on the same totals.
+
+Use --normalize to also show incidents per Datum Cloud active user for each
+week (requires datumctl, see 'datum active-users'). JSON output then includes
+both the raw counts and the normalized rate.
+
+Use --search to fetch incidents with the GitHub search API, which returns only
+issues created in the reported weeks instead of every issue updated since
+then; this needs far fewer requests on busy repositories. Search has its own
+stricter rate limit and returns at most 1000 results, so when either gets in
+the way the issue listing is used instead.
+
+Use --mttr to report mean time to resolution instead: for each week, the mean
+time from creation to close of the incidents closed that week. Incidents that
+are still open are left out of the mean and counted separately by the week
+they were created.
+
+Use --by-daytype to split the weekly totals into incidents created on weekdays
+and on weekends, e.g. for on-call fairness analysis.
+
+Requires GITHUB_TOKEN (or github.token in the config file) for API authentication.`,
+	Args: cobra.ArbitraryArgs,
 	RunE: runIncidents,
 }
 
 func init() {
 	rootCmd.AddCommand(incidentsCmd)
 	incidentsCmd.Flags().Bool("json", false, "Output in JSON format")
+	incidentsCmd.Flags().Bool("raw", false, "Write unprocessed API responses to stdout instead of a report")
+	incidentsCmd.Flags().Int("weeks", 4, "Number of completed weeks to show (1-52)")
+	incidentsCmd.Flags().String("since", "", "First week to show (YYYY-MM-DD, now-4w, last-week, ...)")
+	incidentsCmd.Flags().String("until", "", "Last week to show (YYYY-MM-DD, now, last-week, ...)")
+	incidentsCmd.Flags().Int("warn-threshold", 0, "Color weekly totals above this value yellow (0 = disabled)")
+	incidentsCmd.Flags().Int("crit-threshold", 0, "Color weekly totals above this value red (0 = disabled)")
+	incidentsCmd.Flags().Bool("by-daytype", false, "Split totals into weekday and weekend incidents")
+	incidentsCmd.Flags().Bool("normalize", false, "Also show incidents per Datum Cloud active user")
+	incidentsCmd.Flags().Bool("search", false, "Fetch only matching issues with the GitHub search API")
+	incidentsCmd.Flags().Bool("per-label", false, "With several repositories, show a row per repository and label")
+	incidentsCmd.Flags().Bool("mttr", false, "Show mean time to resolution per week instead of counts")
+	incidentsCmd.Flags().StringArray("label", nil, "Issue label to count as an incident (repeatable, default: :incident/issue and :incident/report)")
 }
 
+// defaultIncidentLabels are counted when no --label is given.
+var defaultIncidentLabels = []string{":incident/issue", ":incident/report"}
+
 type githubIssue struct {
-	Number    int       `json:"number"`
-	Title     string    `json:"title"`
-	CreatedAt time.Time `json:"created_at"`
+	Number    int        `json:"number"`
+	Title     string     `json:"title"`
+	CreatedAt time.Time  `json:"created_at"`
+	ClosedAt  *time.Time `json:"closed_at"`
+	State     string     `json:"state"`
 	Labels    []struct {
 		Name string `json:"name"`
 	} `json:"labels"`
+	PullRequest *struct{} `json:"pull_request"` // set when the issue is a pull request
 }
 
 type weeklyIncidentCounts struct {
-	WeekStart      string
-	IncidentIssues int
-	IncidentReports int
+	WeekStart string
+	Labels    map[string]int // incidents per label
+	Weekend   int            // incidents of any label created on a Saturday or Sunday
 }
 
-func runIncidents(cmd *cobra.Command, args []string) error {
-	repo := args[0]
+func newWeeklyIncidentCounts(week string) weeklyIncidentCounts {
+	return weeklyIncidentCounts{WeekStart: week, Labels: make(map[string]int)}
+}
 
-	token := os.Getenv("GITHUB_TOKEN")
-	if token == "" {
-		return fmt.Errorf("GITHUB_TOKEN environment variable not set")
+// total returns the number of incidents across all labels.
+func (c weeklyIncidentCounts) total() int {
+	total := 0
+	for _, n := range c.Labels {
+		total += n
 	}
+	return total
+}
 
-	// Calculate last 4 week boundaries plus current week
-	weeks := getLast4Weeks()
-	currentWeek := getCurrentWeekStart()
+// weekday returns the number of incidents created Monday through Friday.
+func (c weeklyIncidentCounts) weekday() int {
+	return c.total() - c.Weekend
+}
 
-	fmt.Fprintf(os.Stderr, "Fetching incidents for %s...\n", repo)
+// incidentThresholds holds the warn/crit levels used to classify weekly counts.
+// A zero value disables that level.
+type incidentThresholds struct {
+	Warn int
+	Crit int
+}
 
-	// Fetch issues with incident labels
-	incidentIssues, err := fetchIncidentIssues(token, repo, ":incident/issue")
+// enabled reports whether any threshold is configured.
+func (th incidentThresholds) enabled() bool {
+	return th.Warn > 0 || th.Crit > 0
+}
+
+// status classifies a weekly incident count as "ok", "warn", or "crit".
+func (th incidentThresholds) status(count int) string {
+	if th.Crit > 0 && count > th.Crit {
+		return "crit"
+	}
+	if th.Warn > 0 && count > th.Warn {
+		return "warn"
+	}
+	return "ok"
+}
+
+// color returns the ANSI color code for a weekly incident count.
+func (th incidentThresholds) color(count int) string {
+	switch th.status(count) {
+	case "crit":
+		return ansiRed
+	case "warn":
+		return ansiYellow
+	}
+	return ""
+}
+
+func runIncidents(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	repos, err := argsOrConfig(args, "github.repo", "an org/repo")
 	if err != nil {
-		return fmt.Errorf("failed to fetch incident issues: %w", err)
+		return err
 	}
 
-	incidentReports, err := fetchIncidentIssues(token, repo, ":incident/report")
+	var thresholds incidentThresholds
+	thresholds.Warn, _ = cmd.Flags().GetInt("warn-threshold")
+	thresholds.Crit, _ = cmd.Flags().GetInt("crit-threshold")
+	if thresholds.Warn < 0 || thresholds.Crit < 0 {
+		return fmt.Errorf("thresholds must not be negative")
+	}
+	if thresholds.Warn > 0 && thresholds.Crit > 0 && thresholds.Crit < thresholds.Warn {
+		return fmt.Errorf("--crit-threshold (%d) must not be less than --warn-threshold (%d)", thresholds.Crit, thresholds.Warn)
+	}
+
+	token := githubToken()
+	if token == "" {
+		return errNoGitHubToken
+	}
+	outputRaw := enableRawOutput(cmd)
+
+	// Calculate week boundaries (last 4 by default) plus current week. Only
+	// the first displayed week onward is fetched, so --weeks also sets how far
+	// back issues are requested.
+	numWeeks := weeksFlag(cmd)
+	if numWeeks < 1 || numWeeks > 52 {
+		return fmt.Errorf("--weeks must be between 1 and 52, got %d", numWeeks)
+	}
+	since, _ := cmd.Flags().GetString("since")
+	until, _ := cmd.Flags().GetString("until")
+	weeks, err := resolveWeeks(since, until, numWeeks)
 	if err != nil {
-		return fmt.Errorf("failed to fetch incident reports: %w", err)
+		return err
+	}
+	currentWeek := getCurrentWeekStart()
+	byDayType, _ := cmd.Flags().GetBool("by-daytype")
+	perLabel, _ := cmd.Flags().GetBool("per-label")
+	labels, _ := cmd.Flags().GetStringArray("label")
+	if len(labels) == 0 {
+		labels = defaultIncidentLabels
 	}
 
-	// Count by week
-	counts := make([]weeklyIncidentCounts, len(weeks))
-	for i, week := range weeks {
-		counts[i].WeekStart = week
+	if mttr, _ := cmd.Flags().GetBool("mttr"); mttr {
+		outputJSON := jsonOutput()
+		return runIncidentMTTR(ctx, token, repos, labels, weeks, currentWeek, outputJSON)
 	}
-	currentCounts := weeklyIncidentCounts{WeekStart: currentWeek}
 
-	for _, issue := range incidentIssues {
-		weekStart := getWeekStart(issue.CreatedAt)
-		if weekStart == currentWeek {
-			currentCounts.IncidentIssues++
-		} else {
-			for i, week := range weeks {
-				if weekStart == week {
-					counts[i].IncidentIssues++
-					break
+	useSearch, _ := cmd.Flags().GetBool("search")
+	results, fetchErr := fetchRepoIncidents(ctx, token, repos, labels, weeks, currentWeek, useSearch)
+	if len(results) == 0 {
+		return fetchErr
+	}
+
+	if outputRaw {
+		return fetchErr
+	}
+
+	// A single repository keeps its per-label rows; several repositories get
+	// a row each (or one per repository and label with --per-label).
+	multi := len(repos) > 1
+	target := repos[0]
+	counts, currentCounts := results[0].counts, results[0].current
+	if multi {
+		target = strings.Join(repos, ",")
+		counts, currentCounts = mergeIncidentCounts(results, weeks, currentWeek)
+	}
+	var rows []incidentRow
+	for _, r := range results {
+		if !multi || perLabel {
+			for _, label := range labels {
+				name := label
+				if multi {
+					name = r.repo + " " + label
 				}
+				rows = append(rows, newIncidentRow(name, r.repo, r.counts, r.current, func(c weeklyIncidentCounts) int { return c.Labels[label] }))
 			}
+		} else {
+			rows = append(rows, newIncidentRow(r.repo, r.repo, r.counts, r.current, weeklyIncidentCounts.total))
 		}
 	}
 
-	for _, issue := range incidentReports {
-		weekStart := getWeekStart(issue.CreatedAt)
-		if weekStart == currentWeek {
-			currentCounts.IncidentReports++
-		} else {
+	// Active users per week, when normalizing
+	var users map[string]int
+	if normalize, _ := cmd.Flags().GetBool("normalize"); normalize {
+		users, err = activeUserSeries(ctx, weeks, currentWeek)
+		if err != nil {
+			return err
+		}
+	}
+
+	if wantTemplateData() {
+		data := newTemplateData("incidents", target, weeks, currentWeek)
+		for _, row := range rows {
+			values := map[string]int{currentWeek: row.current}
 			for i, week := range weeks {
-				if weekStart == week {
-					counts[i].IncidentReports++
-					break
-				}
+				values[week] = row.counts[i]
+			}
+			group := ""
+			if multi {
+				group = row.repo
+			}
+			data.addRow(row.label, group, values)
+		}
+		if byDayType {
+			weekday := map[string]int{currentWeek: currentCounts.weekday()}
+			weekend := map[string]int{currentWeek: currentCounts.Weekend}
+			for _, c := range counts {
+				weekday[c.WeekStart] = c.weekday()
+				weekend[c.WeekStart] = c.Weekend
 			}
+			data.addRow("Weekday", "", weekday)
+			data.addRow("Weekend", "", weekend)
+		}
+		if users != nil {
+			data.addRow("Active Users", "", users)
+		}
+		if err := data.output(); err != nil {
+			return err
+		}
+		if outputFormat == "template" {
+			return fetchErr
 		}
 	}
 
 	// Check for JSON output
-	outputJSON, _ := cmd.Flags().GetBool("json")
+	outputJSON := jsonOutput()
 	if outputJSON {
-		printIncidentsJSON(repo, weeks, counts, currentWeek, currentCounts)
-		return nil
+		if err := printIncidentsJSON(results, labels, weeks, currentWeek, thresholds, users, byDayType); err != nil {
+			return err
+		}
+		return fetchErr
 	}
 
 	// Print results using shared table functions
-	fmt.Printf("Incident Counts for %s (Last 4 Weeks)\n\n", repo)
+	printTableTitle("Incident Counts for %s (%s)", target, describeWeeks(weeks))
 
-	table := newWeeklyTable(20, 10, weeks)
-	table.printHeader("Label", currentWeek)
+	labelWidth := 20
+	for _, row := range rows {
+		if len(row.label)+2 > labelWidth {
+			labelWidth = len(row.label) + 2
+		}
+	}
+	table := newWeeklyTable(labelWidth, 10, weeks)
+	if multi {
+		table.printHeader("Repository", currentWeek)
+	} else {
+		table.printHeader("Label", currentWeek)
+	}
 	table.printSeparator(currentWeek)
 
-	// Extract counts into slices
-	issuesCounts := make([]int, len(counts))
-	reportsCounts := make([]int, len(counts))
+	for _, row := range rows {
+		table.printRowWithSlice(row.label, row.counts, row.current)
+	}
+
+	// Print totals
 	totalCounts := make([]int, len(counts))
 	for i, c := range counts {
-		issuesCounts[i] = c.IncidentIssues
-		reportsCounts[i] = c.IncidentReports
-		totalCounts[i] = c.IncidentIssues + c.IncidentReports
+		totalCounts[i] = c.total()
+	}
+	table.printSeparator(currentWeek)
+	currentTotal := currentCounts.total()
+	// Thresholds apply to the weekly total, as the JSON status does
+	if thresholds.enabled() {
+		table.cellColor = thresholds.color
+	}
+	table.printRowWithSlice("Total", totalCounts, currentTotal)
+	table.cellColor = nil
+
+	if byDayType {
+		weekdayCounts := make([]int, len(counts))
+		weekendCounts := make([]int, len(counts))
+		for i, c := range counts {
+			weekdayCounts[i] = c.weekday()
+			weekendCounts[i] = c.Weekend
+		}
+		table.printSeparator(currentWeek)
+		table.printRowWithSlice("Weekday", weekdayCounts, currentCounts.weekday())
+		table.printRowWithSlice("Weekend", weekendCounts, currentCounts.Weekend)
 	}
 
-	// Print rows
-	table.printRowWithSlice(":incident/issue", issuesCounts, currentCounts.IncidentIssues)
-	table.printRowWithSlice(":incident/report", reportsCounts, currentCounts.IncidentReports)
+	if users != nil {
+		table.printSeparator(currentWeek)
+		table.printRow("Active Users", users, currentWeek)
+
+		// The overall rate is incidents per active user-week
+		rates := make([]string, 0, len(weeks)+2)
+		sumIncidents, sumUsers := 0, 0
+		for i, week := range weeks {
+			rates = append(rates, formatPerUser(perUser(totalCounts[i], users[week])))
+			sumIncidents += totalCounts[i]
+			sumUsers += users[week]
+		}
+		rates = append(rates, formatPerUser(perUser(currentTotal, users[currentWeek])))
+		rates = append(rates, formatPerUser(perUser(sumIncidents, sumUsers)))
+		table.printTextRow("Per Active User", rates)
+	}
 
-	// Print totals
-	table.printSeparator(currentWeek)
-	table.printRowWithSlice("Total", totalCounts, currentCounts.IncidentIssues+currentCounts.IncidentReports)
+	return fetchErr
+}
 
-	return nil
+// repoIncidents holds the weekly incident counts for one repository.
+type repoIncidents struct {
+	repo    string
+	counts  []weeklyIncidentCounts
+	current weeklyIncidentCounts
 }
 
+// fetchRepoIncidents counts incidents for each repository concurrently, up to
+// --concurrency at a time. A repository that fails is reported on stderr and
+// left out of the results, so one bad repository does not hide the others;
+// the returned error then names how many failed. With a single repository
+// its error is returned as is.
+func fetchRepoIncidents(ctx context.Context, token string, repos, labels, weeks []string, currentWeek string, useSearch bool) ([]repoIncidents, error) {
+	results := make([]repoIncidents, len(repos))
+	errs := make([]error, len(repos))
+
+	var g errgroup.Group
+	g.SetLimit(concurrency)
+	for i, repo := range repos {
+		g.Go(func() error {
+			progressf("Fetching incidents for %s...\n", repo)
+			counts, current, err := countIncidentsByWeek(ctx, token, repo, labels, weeks, currentWeek, useSearch)
+			if err != nil {
+				errs[i] = fmt.Errorf("%s: %w", repo, err)
+				return nil
+			}
+			results[i] = repoIncidents{repo: repo, counts: counts, current: current}
+			return nil
+		})
+	}
+	g.Wait()
 
-func fetchIncidentIssues(token, repo, label string) ([]githubIssue, error) {
-	var allIssues []githubIssue
-	page := 1
+	// Results gathered before a cancellation are incomplete, so drop them all
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(repos) == 1 && errs[0] != nil {
+		return nil, errs[0]
+	}
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	var ok []repoIncidents
+	failed := 0
+	for i := range repos {
+		if errs[i] != nil {
+			stderrf("Error: %v\n", errs[i])
+			failed++
+			continue
+		}
+		ok = append(ok, results[i])
+	}
+	if failed > 0 {
+		return ok, fmt.Errorf("failed to fetch incidents for %d of %d repositories", failed, len(repos))
+	}
+	return ok, nil
+}
+
+// mergeIncidentCounts sums the weekly counts of several repositories.
+func mergeIncidentCounts(results []repoIncidents, weeks []string, currentWeek string) ([]weeklyIncidentCounts, weeklyIncidentCounts) {
+	add := func(dst *weeklyIncidentCounts, src weeklyIncidentCounts) {
+		for label, n := range src.Labels {
+			dst.Labels[label] += n
+		}
+		dst.Weekend += src.Weekend
+	}
+
+	counts := make([]weeklyIncidentCounts, len(weeks))
+	for i, week := range weeks {
+		counts[i] = newWeeklyIncidentCounts(week)
+	}
+	current := newWeeklyIncidentCounts(currentWeek)
+	for _, r := range results {
+		for i := range weeks {
+			add(&counts[i], r.counts[i])
+		}
+		add(&current, r.current)
+	}
+	return counts, current
+}
 
-	// Get issues from the last 4 weeks
-	since := time.Now().AddDate(0, 0, -28).Format(time.RFC3339)
+// incidentRow is one row of the incidents table.
+type incidentRow struct {
+	label   string
+	repo    string
+	counts  []int
+	current int
+}
 
-	for {
-		url := fmt.Sprintf("https://api.github.com/repos/%s/issues?labels=%s&state=all&since=%s&per_page=100&page=%d",
-			repo, url.QueryEscape(label), since, page)
+func newIncidentRow(label, repo string, counts []weeklyIncidentCounts, current weeklyIncidentCounts, value func(weeklyIncidentCounts) int) incidentRow {
+	row := incidentRow{label: label, repo: repo, counts: make([]int, len(counts)), current: value(current)}
+	for i, c := range counts {
+		row.counts[i] = value(c)
+	}
+	return row
+}
+
+// countIncidentsByWeek fetches issues with each of the given labels for repo
+// and counts them per label for each of the given weeks and the current week.
+func countIncidentsByWeek(ctx context.Context, token, repo string, labels []string, weeks []string, currentWeek string, useSearch bool) ([]weeklyIncidentCounts, weeklyIncidentCounts, error) {
+	// Serve completed weeks from the cache and only fetch from the earliest
+	// week that is missing (normally just the current week).
+	// The cache name is versioned so results cached in an older format are
+	// not misread, and keyed by label set so different --label runs never
+	// share results.
+	cache := newWeekCache("incidents-v4", repo+" "+strings.Join(labels, ","))
+	counts := make([]weeklyIncidentCounts, len(weeks))
+	fetchFrom := currentWeek
+	for i := len(weeks) - 1; i >= 0; i-- {
+		if !cache.load(weeks[i], &counts[i]) {
+			fetchFrom = weeks[i]
+		}
+	}
+	for i, week := range weeks {
+		if week >= fetchFrom {
+			counts[i] = newWeeklyIncidentCounts(week)
+		}
+	}
+	currentCounts := newWeeklyIncidentCounts(currentWeek)
 
-		req, err := http.NewRequest("GET", url, nil)
+	// Fetch issues with each label updated since the first week to refresh,
+	// then count by week, leaving cached weeks untouched
+	since, _ := parseWeekStart(fetchFrom)
+	for _, label := range labels {
+		issues, err := fetchIncidentIssuesFor(ctx, token, repo, label, since, useSearch)
 		if err != nil {
-			return nil, err
+			return nil, weeklyIncidentCounts{}, fmt.Errorf("failed to fetch %s issues: %w", label, err)
+		}
+
+		for _, issue := range issues {
+			weekStart := getWeekStart(issue.CreatedAt)
+			weekend := 0
+			if isWeekend(issue.CreatedAt) {
+				weekend = 1
+			}
+			if weekStart == currentWeek {
+				currentCounts.Labels[label]++
+				currentCounts.Weekend += weekend
+			} else if weekStart >= fetchFrom {
+				for i, week := range weeks {
+					if weekStart == week {
+						counts[i].Labels[label]++
+						counts[i].Weekend += weekend
+						break
+					}
+				}
+			}
+		}
+	}
+
+	for i, week := range weeks {
+		if week >= fetchFrom {
+			cache.store(week, counts[i])
+		}
+	}
+	cache.save()
+
+	return counts, currentCounts, nil
+}
+
+// githubSearchLimit is the most results the search API returns for one query.
+const githubSearchLimit = 1000
+
+// errSearchTooMany is returned by searchIncidentIssues when a query matches
+// more issues than the search API will return.
+var errSearchTooMany = errors.New("too many results for search")
+
+// fetchIncidentIssuesFor returns issues with label created on or after since,
+// using the search API when useSearch is set. Search falls back to the
+// issues listing when it is rate limited or has too many results.
+func fetchIncidentIssuesFor(ctx context.Context, token, repo, label string, since time.Time, useSearch bool) ([]githubIssue, error) {
+	if useSearch {
+		issues, err := searchIncidentIssues(ctx, token, repo, label, since)
+		if !errors.Is(err, errGitHubRateLimited) && !errors.Is(err, errSearchTooMany) {
+			return issues, err
 		}
+		stderrf("Search unavailable for %s (%v); listing issues instead\n", repo, err)
+	}
+	return fetchIssues(ctx, token, repo, label, since)
+}
+
+// searchIncidentIssues uses the search API to fetch only the issues in repo
+// with label created on or after since. Search is rate limited separately
+// and more strictly than the rest of the API, so it is not retried.
+func searchIncidentIssues(ctx context.Context, token, repo, label string, since time.Time) ([]githubIssue, error) {
+	var allIssues []githubIssue
+	progress := newFetchProgress("issues")
+	defer progress.done()
 
-		req.Header.Set("Authorization", "Bearer "+token)
-		req.Header.Set("Accept", "application/vnd.github+json")
-		req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	client := newHTTPClient()
 
-		resp, err := client.Do(req)
+	query := fmt.Sprintf("repo:%s label:%q created:>=%s", repo, label, since.UTC().Format(time.RFC3339))
+	next := "https://api.github.com/search/issues?per_page=100&q=" + url.QueryEscape(query)
+	for next != "" {
+		body, nextURL, err := githubRequestRetries(ctx, client, token, next, 0)
 		if err != nil {
 			return nil, err
 		}
 
-		if resp.StatusCode == 404 {
-			resp.Body.Close()
-			return nil, fmt.Errorf("repository not found: %s", repo)
+		var result struct {
+			TotalCount int           `json:"total_count"`
+			Items      []githubIssue `json:"items"`
+		}
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, err
+		}
+		if result.TotalCount > githubSearchLimit {
+			return nil, fmt.Errorf("%w (%d)", errSearchTooMany, result.TotalCount)
 		}
 
-		if resp.StatusCode != 200 {
-			body, _ := io.ReadAll(resp.Body)
-			resp.Body.Close()
-			return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		writeRaw(body)
+
+		allIssues = append(allIssues, result.Items...)
+		progress.page(len(result.Items))
+		next = nextURL
+	}
+
+	return allIssues, nil
+}
+
+// fetchIssues lists the issues in repo updated on or after since, with label
+// unless it is empty. The listing includes pull requests; see
+// githubIssue.PullRequest.
+func fetchIssues(ctx context.Context, token, repo, label string, since time.Time) ([]githubIssue, error) {
+	var allIssues []githubIssue
+	progress := newFetchProgress("issues")
+	defer progress.done()
+
+	client := newHTTPClient()
+
+	// Follow the Link header until there is no next page
+	next := fmt.Sprintf("https://api.github.com/repos/%s/issues?state=all&since=%s&per_page=100",
+		repo, since.UTC().Format(time.RFC3339))
+	if label != "" {
+		next += "&labels=" + url.QueryEscape(label)
+	}
+	for next != "" {
+		body, nextURL, err := githubRequestPage(ctx, client, token, next)
+		if errors.Is(err, errGitHubNotFound) {
+			return nil, fmt.Errorf("repository not found: %s", repo)
+		}
+		if err != nil {
+			return nil, err
 		}
 
 		var issues []githubIssue
-		if err := json.NewDecoder(resp.Body).Decode(&issues); err != nil {
-			resp.Body.Close()
+		if err := json.Unmarshal(body, &issues); err != nil {
 			return nil, err
 		}
-		resp.Body.Close()
 
-		if len(issues) == 0 {
-			break
-		}
+		writeRaw(body)
 
 		allIssues = append(allIssues, issues...)
-		page++
+		progress.page(len(issues))
+		next = nextURL
 	}
 
 	return allIssues, nil
 }
 
-func printIncidentsJSON(repo string, weeks []string, counts []weeklyIncidentCounts, currentWeek string, currentCounts weeklyIncidentCounts) {
-	type WeekData struct {
-		WeekEnding     string `json:"week_ending"`
-		IncidentIssue  int    `json:"incident_issue"`
-		IncidentReport int    `json:"incident_report"`
-		Total          int    `json:"total"`
+// printIncidentsJSON prints the incidents report for one repository, or for
+// several as a "repositories" list plus their "combined" counts.
+func printIncidentsJSON(results []repoIncidents, labels []string, weeks []string, currentWeek string, thresholds incidentThresholds, users map[string]int, byDayType bool) error {
+	if len(results) == 1 {
+		r := results[0]
+		return printJSON(incidentsJSON(r.repo, labels, weeks, r.counts, currentWeek, r.current, thresholds, users, byDayType))
 	}
-	type Output struct {
-		Repository  string     `json:"repository"`
-		Weeks       []WeekData `json:"weeks"`
-		CurrentWeek WeekData   `json:"current_week"`
-		Totals      struct {
-			IncidentIssue  int `json:"incident_issue"`
-			IncidentReport int `json:"incident_report"`
-			Total          int `json:"total"`
-		} `json:"totals"`
+
+	var output IncidentsMultiJSON
+	var repos []string
+	for _, r := range results {
+		output.Repositories = append(output.Repositories, incidentsJSON(r.repo, labels, weeks, r.counts, currentWeek, r.current, thresholds, nil, byDayType))
+		repos = append(repos, r.repo)
 	}
+	counts, current := mergeIncidentCounts(results, weeks, currentWeek)
+	output.Combined = incidentsJSON(strings.Join(repos, ","), labels, weeks, counts, currentWeek, current, thresholds, users, byDayType)
+	return printJSONList(output.Repositories, output)
+}
 
-	var output Output
+// incidentsJSON builds the JSON report for one set of weekly counts.
+func incidentsJSON(repo string, labels []string, weeks []string, counts []weeklyIncidentCounts, currentWeek string, currentCounts weeklyIncidentCounts, thresholds incidentThresholds, users map[string]int, byDayType bool) IncidentsRepoJSON {
+	// labelCounts returns the counts for every requested label, including zeros.
+	labelCounts := func(c weeklyIncidentCounts) map[string]int {
+		m := make(map[string]int, len(labels))
+		for _, label := range labels {
+			m[label] = c.Labels[label]
+		}
+		return m
+	}
+	// splitDayType fills in the weekday/weekend fields when --by-daytype is set.
+	splitDayType := func(w *IncidentsWeekJSON, c weeklyIncidentCounts) {
+		if !byDayType {
+			return
+		}
+		weekday, weekend := c.weekday(), c.Weekend
+		w.Weekday = &weekday
+		w.Weekend = &weekend
+	}
+	// normalize fills in the active-user fields when --normalize is set.
+	normalize := func(w *IncidentsWeekJSON, week string) {
+		if users == nil {
+			return
+		}
+		n := users[week]
+		w.ActiveUsers = &n
+		if rate, ok := perUser(w.Total, n); ok {
+			w.PerActiveUser = &rate
+		}
+	}
+	var output IncidentsRepoJSON
 	output.Repository = repo
+	output.Totals.Labels = labelCounts(weeklyIncidentCounts{})
 
 	for i, week := range weeks {
-		weekData := WeekData{
-			WeekEnding:     weekStartToEnd(week),
-			IncidentIssue:  counts[i].IncidentIssues,
-			IncidentReport: counts[i].IncidentReports,
-			Total:          counts[i].IncidentIssues + counts[i].IncidentReports,
+		weekData := IncidentsWeekJSON{
+			WeekEnding: weekStartToEnd(week),
+			Labels:     labelCounts(counts[i]),
+			Total:      counts[i].total(),
+		}
+		if thresholds.enabled() {
+			weekData.Status = thresholds.status(weekData.Total)
 		}
+		splitDayType(&weekData, counts[i])
+		normalize(&weekData, week)
 		output.Weeks = append(output.Weeks, weekData)
-		output.Totals.IncidentIssue += counts[i].IncidentIssues
-		output.Totals.IncidentReport += counts[i].IncidentReports
+		for _, label := range labels {
+			output.Totals.Labels[label] += counts[i].Labels[label]
+		}
+		output.Totals.Total += weekData.Total
 	}
-	output.Totals.Total = output.Totals.IncidentIssue + output.Totals.IncidentReport
 
-	output.CurrentWeek = WeekData{
-		WeekEnding:     weekStartToEnd(currentWeek),
-		IncidentIssue:  currentCounts.IncidentIssues,
-		IncidentReport: currentCounts.IncidentReports,
-		Total:          currentCounts.IncidentIssues + currentCounts.IncidentReports,
+	output.CurrentWeek = IncidentsWeekJSON{
+		WeekEnding: weekStartToEnd(currentWeek),
+		Labels:     labelCounts(currentCounts),
+		Total:      currentCounts.total(),
+	}
+	if thresholds.enabled() {
+		output.CurrentWeek.Status = thresholds.status(output.CurrentWeek.Total)
 	}
+	splitDayType(&output.CurrentWeek, currentCounts)
+	normalize(&output.CurrentWeek, currentWeek)
 
-	b, _ := json.MarshalIndent(output, "", "  ")
-	fmt.Println(string(b))
+	return output
 }
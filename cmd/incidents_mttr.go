@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// incidentMTTR holds resolution times for incidents, bucketed by the week
+// they were closed, and counts of incidents still open, bucketed by the week
+// they were created.
+type incidentMTTR struct {
+	resolved  map[string][]time.Duration
+	stillOpen map[string]int
+}
+
+// computeIncidentMTTR fetches issues with each label updated since the first
+// week and measures how long each closed incident stayed open. Incidents from
+// all repos are pooled, and an issue with several of the labels is only
+// counted once.
+func computeIncidentMTTR(ctx context.Context, token string, repos, labels []string, weeks []string) (incidentMTTR, error) {
+	mttr := incidentMTTR{resolved: make(map[string][]time.Duration), stillOpen: make(map[string]int)}
+	since, _ := parseWeekStart(weeks[0])
+
+	seen := make(map[string]bool)
+	for _, repo := range repos {
+		progressf("Fetching incidents for %s...\n", repo)
+		for _, label := range labels {
+			issues, err := fetchIssues(ctx, token, repo, label, since)
+			if err != nil {
+				return mttr, fmt.Errorf("%s: failed to fetch %s issues: %w", repo, label, err)
+			}
+			for _, issue := range issues {
+				key := fmt.Sprintf("%s#%d", repo, issue.Number)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+
+				if issue.State == "open" || issue.ClosedAt == nil {
+					mttr.stillOpen[getWeekStart(issue.CreatedAt)]++
+					continue
+				}
+				week := getWeekStart(*issue.ClosedAt)
+				mttr.resolved[week] = append(mttr.resolved[week], issue.ClosedAt.Sub(issue.CreatedAt))
+			}
+		}
+	}
+	return mttr, nil
+}
+
+// meanDuration returns the mean of durations, or false if there are none.
+func meanDuration(durations []time.Duration) (time.Duration, bool) {
+	if len(durations) == 0 {
+		return 0, false
+	}
+	var sum time.Duration
+	for _, d := range durations {
+		sum += d
+	}
+	return sum / time.Duration(len(durations)), true
+}
+
+// formatMeanDuration renders the mean of durations with humanizeDuration, or "-" if empty.
+func formatMeanDuration(durations []time.Duration) string {
+	mean, ok := meanDuration(durations)
+	if !ok {
+		return "-"
+	}
+	return humanizeDuration(mean)
+}
+
+// runIncidentMTTR prints mean time to resolution per week for --mttr.
+func runIncidentMTTR(ctx context.Context, token string, repos, labels []string, weeks []string, currentWeek string, outputJSON bool) error {
+	repo := strings.Join(repos, ",")
+	mttr, err := computeIncidentMTTR(ctx, token, repos, labels, weeks)
+	if err != nil {
+		return err
+	}
+	if rawOutput != nil {
+		return nil
+	}
+
+	var all []time.Duration
+	allOpen := 0
+	for _, week := range weeks {
+		all = append(all, mttr.resolved[week]...)
+		allOpen += mttr.stillOpen[week]
+	}
+
+	if outputJSON {
+		return printIncidentMTTRJSON(repo, weeks, mttr, currentWeek, all, allOpen)
+	}
+
+	printTableTitle("Incident MTTR for %s (%s)", repo, describeWeeks(weeks))
+
+	table := newWeeklyTable(20, 10, weeks)
+	table.printHeader("Metric", currentWeek)
+	table.printSeparator(currentWeek)
+
+	means := make([]string, 0, len(weeks)+2)
+	resolvedCounts := make([]int, len(weeks))
+	openCounts := make([]int, len(weeks))
+	for i, week := range weeks {
+		means = append(means, formatMeanDuration(mttr.resolved[week]))
+		resolvedCounts[i] = len(mttr.resolved[week])
+		openCounts[i] = mttr.stillOpen[week]
+	}
+	means = append(means, formatMeanDuration(mttr.resolved[currentWeek]))
+	means = append(means, formatMeanDuration(all))
+
+	table.printTextRow("MTTR", means)
+	table.printRowWithSlice("Resolved", resolvedCounts, len(mttr.resolved[currentWeek]))
+	table.printRowWithSlice("Still Open", openCounts, mttr.stillOpen[currentWeek])
+
+	return nil
+}
+
+func printIncidentMTTRJSON(repo string, weeks []string, mttr incidentMTTR, currentWeek string, all []time.Duration, allOpen int) error {
+	toWeekData := func(weekEnding string, durations []time.Duration, open int) MTTRWeekJSON {
+		data := MTTRWeekJSON{WeekEnding: weekEnding, Resolved: len(durations), StillOpen: open}
+		if mean, ok := meanDuration(durations); ok {
+			seconds := mean.Seconds()
+			data.MTTRSeconds = &seconds
+		}
+		return data
+	}
+
+	output := MTTRJSON{Repository: repo}
+	for _, week := range weeks {
+		output.Weeks = append(output.Weeks, toWeekData(weekStartToEnd(week), mttr.resolved[week], mttr.stillOpen[week]))
+	}
+	output.CurrentWeek = toWeekData(weekStartToEnd(currentWeek), mttr.resolved[currentWeek], mttr.stillOpen[currentWeek])
+	output.Totals = toWeekData("", all, allOpen)
+
+	return printJSON(output)
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var issuesCmd = &cobra.Command{
+	Use:   "issues-opened-vs-closed [org]/[repo]",
+	Short: "Display issues opened and closed by week for a repository",
+	Long: `Fetch the issues in a repository updated during the reported window and count
+those opened and those closed each week, with the net change (opened minus
+closed) as a burndown. Pull requests are not counted.
+
+Use --label to only count issues with a label.
+
+Displays counts for the last 4 completed weeks, or as many as weeks in the
+config file sets.
+
+Requires GITHUB_TOKEN (or github.token in the config file) for API authentication.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runIssues,
+}
+
+func init() {
+	githubCmd.AddCommand(issuesCmd)
+	issuesCmd.Flags().Bool("json", false, "Output in JSON format")
+	issuesCmd.Flags().String("label", "", "Only count issues with this label")
+}
+
+func runIssues(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	args, err := argsOrConfig(args, "github.repo", "an org/repo")
+	if err != nil {
+		return err
+	}
+	repo := args[0]
+	outputJSON := jsonOutput()
+	label, _ := cmd.Flags().GetString("label")
+
+	token := githubToken()
+	if token == "" {
+		return errNoGitHubToken
+	}
+
+	weeks := getLastNWeeks(defaultWeekCount())
+	currentWeek := getCurrentWeekStart()
+	since, _ := parseWeekStart(weeks[0])
+
+	progressf("Fetching issues for %s...\n", repo)
+	issues, err := fetchIssues(ctx, token, repo, label, since)
+	if err != nil {
+		return fmt.Errorf("failed to fetch issues: %w", err)
+	}
+
+	// Opening or closing an issue updates it, so every issue opened or
+	// closed in the window is in issues
+	opened := make(map[string]int)
+	closed := make(map[string]int)
+	for _, issue := range issues {
+		if issue.PullRequest != nil {
+			continue
+		}
+		if !issue.CreatedAt.Before(since) {
+			opened[getWeekStart(issue.CreatedAt)]++
+		}
+		if issue.ClosedAt != nil && !issue.ClosedAt.Before(since) {
+			closed[getWeekStart(*issue.ClosedAt)]++
+		}
+	}
+
+	if wantTemplateData() {
+		data := newTemplateData("github issues-opened-vs-closed", repo, weeks, currentWeek)
+		data.addRow("Opened", "", opened)
+		data.addRow("Closed", "", closed)
+		if err := data.output(); err != nil || outputFormat == "template" {
+			return err
+		}
+	}
+
+	if outputJSON {
+		return printIssuesJSON(repo, label, weeks, currentWeek, opened, closed)
+	}
+
+	title := repo
+	if label != "" {
+		title = fmt.Sprintf("%s (label %s)", repo, label)
+	}
+	printTableTitle("Issues for %s (%s)", title, describeWeeks(weeks))
+
+	table := newWeeklyTable(20, 10, weeks)
+	table.printHeader("Issues", currentWeek)
+	table.printSeparator(currentWeek)
+	table.printRow("Opened", opened, currentWeek)
+	table.printRow("Closed", closed, currentWeek)
+	table.printSeparator(currentWeek)
+
+	net := make([]string, 0, len(weeks)+2)
+	total := 0
+	for _, week := range weeks {
+		n := opened[week] - closed[week]
+		net = append(net, formatNet(n))
+		total += n
+	}
+	net = append(net, formatNet(opened[currentWeek]-closed[currentWeek]), formatNet(total))
+	table.printTextRow("Net", net)
+
+	return nil
+}
+
+// formatNet formats a net change with an explicit sign, e.g. "+3" or "-2".
+func formatNet(n int) string {
+	if n == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("%+d", n)
+}
+
+func printIssuesJSON(repo, label string, weeks []string, currentWeek string, opened, closed map[string]int) error {
+	toWeekData := func(week string) IssuesWeekJSON {
+		return IssuesWeekJSON{
+			WeekEnding: weekStartToEnd(week),
+			Opened:     opened[week],
+			Closed:     closed[week],
+			Net:        opened[week] - closed[week],
+		}
+	}
+
+	output := IssuesJSON{Repository: repo, Label: label}
+	for _, week := range weeks {
+		data := toWeekData(week)
+		output.Weeks = append(output.Weeks, data)
+		output.Totals.Opened += data.Opened
+		output.Totals.Closed += data.Closed
+		output.Totals.Net += data.Net
+	}
+	output.CurrentWeek = toWeekData(currentWeek)
+
+	return printJSON(output)
+}
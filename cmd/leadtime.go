@@ -0,0 +1,351 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var leadTimeCmd = &cobra.Command{
+	Use:   "lead-time [org]/[repo]",
+	Short: "Display median merge-to-deploy lead time by week for a repository",
+	Long: `Correlate merged pull requests with deployments to compute the lead time for
+changes: the time from a pull request being merged to the first deployment
+created at or after the merge.
+
+Deployments come from the GitHub deployments API by default (optionally scoped
+with --environment). Use --source releases to treat published releases as
+deployments instead.
+
+Pull requests are bucketed by the week they were merged, and the median lead
+time is reported for each week. Pull requests that have not been deployed yet
+are excluded.
+
+Displays the last 4 completed weeks, or as many as weeks in the config file
+sets.
+
+Requires GITHUB_TOKEN (or github.token in the config file) for API authentication.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runLeadTime,
+}
+
+func init() {
+	githubCmd.AddCommand(leadTimeCmd)
+	leadTimeCmd.Flags().Bool("json", false, "Output in JSON format")
+	leadTimeCmd.Flags().String("source", "deployments", "Deployment events to use: deployments or releases")
+	leadTimeCmd.Flags().String("environment", "", "Only use deployments to this environment")
+}
+
+type githubPull struct {
+	Number    int        `json:"number"`
+	Title     string     `json:"title"`
+	State     string     `json:"state"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	ClosedAt  *time.Time `json:"closed_at"`
+	MergedAt  *time.Time `json:"merged_at"`
+}
+
+type githubDeployment struct {
+	SHA         string    `json:"sha"`
+	Environment string    `json:"environment"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type githubRelease struct {
+	TagName     string               `json:"tag_name"`
+	Name        string               `json:"name"`
+	Draft       bool                 `json:"draft"`
+	PublishedAt *time.Time           `json:"published_at"`
+	Assets      []githubReleaseAsset `json:"assets"`
+}
+
+type githubReleaseAsset struct {
+	Name          string `json:"name"`
+	DownloadCount int    `json:"download_count"`
+}
+
+func runLeadTime(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	args, err := argsOrConfig(args, "github.repo", "an org/repo")
+	if err != nil {
+		return err
+	}
+	repo := args[0]
+	outputJSON := jsonOutput()
+	source, _ := cmd.Flags().GetString("source")
+	environment, _ := cmd.Flags().GetString("environment")
+
+	if source != "deployments" && source != "releases" {
+		return fmt.Errorf("invalid --source %q (must be deployments or releases)", source)
+	}
+
+	token := githubToken()
+	if token == "" {
+		return errNoGitHubToken
+	}
+
+	weeks := getLastNWeeks(defaultWeekCount())
+	currentWeek := getCurrentWeekStart()
+	since, _ := parseWeekStart(weeks[0])
+
+	progressf("Fetching merged pull requests for %s...\n", repo)
+	pulls, err := fetchMergedPulls(ctx, token, repo, since)
+	if err != nil {
+		return fmt.Errorf("failed to fetch pull requests: %w", err)
+	}
+
+	progressf("Fetching %s for %s...\n", source, repo)
+	var deployTimes []time.Time
+	if source == "releases" {
+		deployTimes, err = fetchReleaseTimes(ctx, token, repo, since)
+	} else {
+		deployTimes, err = fetchDeploymentTimes(ctx, token, repo, environment, since)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", source, err)
+	}
+	sort.Slice(deployTimes, func(i, j int) bool { return deployTimes[i].Before(deployTimes[j]) })
+
+	// Lead time is measured to the first deployment at or after the merge.
+	samples := make(map[string][]time.Duration)
+	var all []time.Duration
+	for _, pr := range pulls {
+		merged := *pr.MergedAt
+		i := sort.Search(len(deployTimes), func(i int) bool { return !deployTimes[i].Before(merged) })
+		if i == len(deployTimes) {
+			continue
+		}
+		week := getWeekStart(merged)
+		lead := deployTimes[i].Sub(merged)
+		samples[week] = append(samples[week], lead)
+		if week != currentWeek {
+			all = append(all, lead)
+		}
+	}
+
+	if outputJSON {
+		return printLeadTimeJSON(repo, source, weeks, samples, currentWeek, all)
+	}
+
+	printTableTitle("Lead Time for %s (%s)", repo, describeWeeks(weeks))
+
+	table := newWeeklyTable(20, 10, weeks)
+	table.printHeader("Metric", currentWeek)
+	table.printSeparator(currentWeek)
+
+	medians := make([]string, 0, len(weeks)+2)
+	sampleCounts := make([]int, len(weeks))
+	for i, week := range weeks {
+		medians = append(medians, formatMedianDuration(samples[week]))
+		sampleCounts[i] = len(samples[week])
+	}
+	medians = append(medians, formatMedianDuration(samples[currentWeek]))
+	medians = append(medians, formatMedianDuration(all))
+
+	table.printTextRow("Median Lead Time", medians)
+	table.printRowWithSlice("Deployed PRs", sampleCounts, len(samples[currentWeek]))
+
+	return nil
+}
+
+// medianDuration returns the median of durations, or false if there are none.
+func medianDuration(durations []time.Duration) (time.Duration, bool) {
+	if len(durations) == 0 {
+		return 0, false
+	}
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2, true
+	}
+	return sorted[mid], true
+}
+
+// formatMedianDuration renders the median of durations with humanizeDuration, or "-" if empty.
+func formatMedianDuration(durations []time.Duration) string {
+	median, ok := medianDuration(durations)
+	if !ok {
+		return "-"
+	}
+	return humanizeDuration(median)
+}
+
+// fetchMergedPulls returns pull requests in repo merged on or after since.
+func fetchMergedPulls(ctx context.Context, token, repo string, since time.Time) ([]githubPull, error) {
+	pulls, err := fetchPullsUpdatedSince(ctx, token, repo, "closed", since)
+	if err != nil {
+		return nil, err
+	}
+
+	var merged []githubPull
+	for _, pr := range pulls {
+		if pr.MergedAt != nil && !pr.MergedAt.Before(since) {
+			merged = append(merged, pr)
+		}
+	}
+	return merged, nil
+}
+
+// fetchPullsUpdatedSince returns pull requests in repo with the given state
+// (open, closed, or all) that were last updated on or after since. Pull
+// requests are listed by most recently updated, so paging stops once a page
+// ends with a pull request last updated before since.
+func fetchPullsUpdatedSince(ctx context.Context, token, repo, state string, since time.Time) ([]githubPull, error) {
+	var allPulls []githubPull
+	progress := newFetchProgress("pull requests")
+	defer progress.done()
+
+	client := newHTTPClient()
+
+	// Follow the Link header until there is no next page
+	next := fmt.Sprintf("https://api.github.com/repos/%s/pulls?state=%s&sort=updated&direction=desc&per_page=100", repo, state)
+	for next != "" {
+		body, nextURL, err := githubRequestPage(ctx, client, token, next)
+		if errors.Is(err, errGitHubNotFound) {
+			return nil, fmt.Errorf("repository not found: %s", repo)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var pulls []githubPull
+		if err := json.Unmarshal(body, &pulls); err != nil {
+			return nil, err
+		}
+
+		writeRaw(body)
+		progress.page(len(pulls))
+
+		for _, pr := range pulls {
+			if !pr.UpdatedAt.Before(since) {
+				allPulls = append(allPulls, pr)
+			}
+		}
+
+		if len(pulls) > 0 && pulls[len(pulls)-1].UpdatedAt.Before(since) {
+			break
+		}
+		next = nextURL
+	}
+
+	return allPulls, nil
+}
+
+// fetchDeploymentTimes returns the creation times of deployments in repo
+// created on or after since, optionally limited to one environment.
+func fetchDeploymentTimes(ctx context.Context, token, repo, environment string, since time.Time) ([]time.Time, error) {
+	var times []time.Time
+	progress := newFetchProgress("deployments")
+	defer progress.done()
+
+	client := newHTTPClient()
+
+	// Follow the Link header until there is no next page
+	next := fmt.Sprintf("https://api.github.com/repos/%s/deployments?per_page=100", repo)
+	if environment != "" {
+		next += "&environment=" + url.QueryEscape(environment)
+	}
+	for next != "" {
+		body, nextURL, err := githubRequestPage(ctx, client, token, next)
+		if errors.Is(err, errGitHubNotFound) {
+			return nil, fmt.Errorf("repository not found: %s", repo)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var deployments []githubDeployment
+		if err := json.Unmarshal(body, &deployments); err != nil {
+			return nil, err
+		}
+
+		writeRaw(body)
+		progress.page(len(deployments))
+
+		// Deployments are returned newest first
+		for _, d := range deployments {
+			if !d.CreatedAt.Before(since) {
+				times = append(times, d.CreatedAt)
+			}
+		}
+		if len(deployments) > 0 && deployments[len(deployments)-1].CreatedAt.Before(since) {
+			break
+		}
+		next = nextURL
+	}
+
+	return times, nil
+}
+
+// fetchReleaseTimes returns the publish times of non-draft releases in repo
+// published on or after since.
+func fetchReleaseTimes(ctx context.Context, token, repo string, since time.Time) ([]time.Time, error) {
+	var times []time.Time
+	progress := newFetchProgress("releases")
+	defer progress.done()
+
+	client := newHTTPClient()
+
+	// Follow the Link header until there is no next page
+	next := fmt.Sprintf("https://api.github.com/repos/%s/releases?per_page=100", repo)
+	for next != "" {
+		body, nextURL, err := githubRequestPage(ctx, client, token, next)
+		if errors.Is(err, errGitHubNotFound) {
+			return nil, fmt.Errorf("repository not found: %s", repo)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var releases []githubRelease
+		if err := json.Unmarshal(body, &releases); err != nil {
+			return nil, err
+		}
+
+		writeRaw(body)
+		progress.page(len(releases))
+
+		// Releases are returned newest first
+		for _, r := range releases {
+			if !r.Draft && r.PublishedAt != nil && !r.PublishedAt.Before(since) {
+				times = append(times, *r.PublishedAt)
+			}
+		}
+		if len(releases) > 0 {
+			if last := releases[len(releases)-1]; last.PublishedAt != nil && last.PublishedAt.Before(since) {
+				break
+			}
+		}
+		next = nextURL
+	}
+
+	return times, nil
+}
+
+func printLeadTimeJSON(repo, source string, weeks []string, samples map[string][]time.Duration, currentWeek string, all []time.Duration) error {
+	toWeekData := func(weekEnding string, durations []time.Duration) LeadTimeWeekJSON {
+		data := LeadTimeWeekJSON{WeekEnding: weekEnding, SampleSize: len(durations)}
+		if median, ok := medianDuration(durations); ok {
+			seconds := median.Seconds()
+			data.MedianSeconds = &seconds
+		}
+		return data
+	}
+
+	output := LeadTimeJSON{Repository: repo, Source: source}
+	for _, week := range weeks {
+		output.Weeks = append(output.Weeks, toWeekData(weekStartToEnd(week), samples[week]))
+	}
+	output.CurrentWeek = toWeekData(weekStartToEnd(currentWeek), samples[currentWeek])
+	output.Totals = toWeekData("", all)
+
+	return printJSON(output)
+}
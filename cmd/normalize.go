@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+)
+
+// activeUserSeries returns the Datum Cloud active-user count for each of the
+// given weeks and the current week, for use as a --normalize denominator.
+func activeUserSeries(ctx context.Context, weeks []string, currentWeek string) (map[string]int, error) {
+	datumctl, err := findDatumctl()
+	if err != nil {
+		return nil, fmt.Errorf("--normalize needs datumctl: %w", err)
+	}
+	progressf("Querying Datum Cloud audit logs for active users...\n")
+	users, _, err := countActiveUsersByWeek(ctx, datumctl, 0, weeks, currentWeek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count active users: %w", err)
+	}
+	return users, nil
+}
+
+// perUser divides count by the number of active users. It reports false when
+// there were no active users to divide by.
+func perUser(count, users int) (float64, bool) {
+	if users == 0 {
+		return 0, false
+	}
+	return float64(count) / float64(users), true
+}
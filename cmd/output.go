@@ -0,0 +1,700 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// jsonFields holds the value of the persistent --fields flag: a comma-separated
+// list of dotted paths used to filter JSON output.
+var jsonFields string
+
+// outputFile holds the value of the persistent --output-file flag.
+var outputFile string
+
+// stdout receives the report body: os.Stdout, or the --output-file file.
+// Progress and warnings always go to os.Stderr.
+var stdout io.Writer = os.Stdout
+
+// outputFileHandle is the open --output-file file, if any.
+var outputFileHandle *os.File
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&jsonFields, "fields", "", "Comma-separated dotted paths to keep in JSON output (e.g. \"weeks.count,total\")")
+	rootCmd.PersistentFlags().StringVar(&outputFile, "output-file", "", "Write the report to this file instead of stdout (truncated if it exists)")
+}
+
+// openOutputFile points stdout at --output-file when it is set.
+func openOutputFile() error {
+	if outputFile == "" {
+		return nil
+	}
+	f, err := os.Create(outputFile)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	stdout = f
+	outputFileHandle = f
+	return nil
+}
+
+// closeOutputFile closes the --output-file file, if one was opened.
+func closeOutputFile() error {
+	if outputFileHandle == nil {
+		return nil
+	}
+	return outputFileHandle.Close()
+}
+
+// jsonOutput reports whether --output selects JSON or JSON Lines.
+func jsonOutput() bool {
+	return outputFormat == "json" || outputFormat == "jsonl"
+}
+
+// printJSON writes v to stdout as indented JSON, keeping only the --fields
+// paths when that flag is set. With --output jsonl it writes compact JSON
+// Lines instead: one line per element when v is a list, otherwise v on one
+// line.
+func printJSON(v interface{}) error {
+	if jsonFields != "" {
+		filtered, err := selectJSONFields(v, jsonFields)
+		if err != nil {
+			return err
+		}
+		v = filtered
+	}
+	if outputFormat == "jsonl" {
+		return printJSONLines(v)
+	}
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, string(b))
+	return nil
+}
+
+// printJSONList prints doc, or with --output jsonl just records, one per
+// line. Reports whose document wraps a per-repo or per-job list use it so
+// JSON Lines consumers get the list elements with their usual schema.
+func printJSONList(records, doc interface{}) error {
+	if outputFormat == "jsonl" {
+		return printJSON(records)
+	}
+	return printJSON(doc)
+}
+
+// printJSONLines writes v as compact JSON Lines. A nil list writes nothing.
+func printJSONLines(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if string(b) == "null" {
+		return nil
+	}
+	if b[0] != '[' {
+		fmt.Fprintln(stdout, string(b))
+		return nil
+	}
+	var elems []json.RawMessage
+	if err := json.Unmarshal(b, &elems); err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		fmt.Fprintln(stdout, string(elem))
+	}
+	return nil
+}
+
+// fieldTree is a set of requested paths. A nil subtree keeps the whole value.
+type fieldTree map[string]fieldTree
+
+// selectJSONFields filters v down to the given comma-separated dotted paths.
+// Arrays are transparent: a path applies to every element. Paths are checked
+// against v's type, so a field is accepted even when this run's document
+// leaves it out (an empty list, an omitempty field); map keys and other
+// dynamic parts are checked against the document itself. A path that exists
+// in neither is an error.
+func selectJSONFields(v interface{}, fields string) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+
+	available := make(map[string]bool)
+	if v != nil {
+		collectSchemaPaths(schemaForType(reflect.TypeOf(v)), "", available)
+	}
+	collectJSONPaths(doc, "", available)
+
+	tree := make(fieldTree)
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !available[field] {
+			return nil, fmt.Errorf("unknown field %q (available: %s)", field, strings.Join(sortedKeys(available), ", "))
+		}
+		node := tree
+		parts := strings.Split(field, ".")
+		for i, part := range parts {
+			child, ok := node[part]
+			if i == len(parts)-1 {
+				node[part] = nil
+				break
+			}
+			if ok && child == nil {
+				break // a parent path already keeps the whole value
+			}
+			if !ok {
+				child = make(fieldTree)
+				node[part] = child
+			}
+			node = child
+		}
+	}
+
+	return filterJSON(doc, tree), nil
+}
+
+// collectSchemaPaths records every dotted object path the schema s declares.
+func collectSchemaPaths(s *jsonSchema, prefix string, paths map[string]bool) {
+	if s.Items != nil {
+		collectSchemaPaths(s.Items, prefix, paths)
+	}
+	for _, alt := range s.OneOf {
+		collectSchemaPaths(alt, prefix, paths)
+	}
+	if s.Properties == nil {
+		return
+	}
+	for _, key := range s.Properties.names {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		paths[path] = true
+		collectSchemaPaths(s.Properties.schemas[key], path, paths)
+	}
+}
+
+// collectJSONPaths records every dotted object path present in v.
+func collectJSONPaths(v interface{}, prefix string, paths map[string]bool) {
+	switch val := v.(type) {
+	case []interface{}:
+		for _, elem := range val {
+			collectJSONPaths(elem, prefix, paths)
+		}
+	case map[string]interface{}:
+		for key, child := range val {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			paths[path] = true
+			collectJSONPaths(child, path, paths)
+		}
+	}
+}
+
+// filterJSON keeps only the parts of v selected by tree.
+func filterJSON(v interface{}, tree fieldTree) interface{} {
+	if tree == nil {
+		return v
+	}
+	switch val := v.(type) {
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, elem := range val {
+			out[i] = filterJSON(elem, tree)
+		}
+		return out
+	case map[string]interface{}:
+		out := make(map[string]interface{})
+		for key, subtree := range tree {
+			if child, ok := val[key]; ok {
+				out[key] = filterJSON(child, subtree)
+			}
+		}
+		return out
+	}
+	return v
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// JSON output documents. Every command's --output json document is built from
+// the exported types below: they are the output contract, pinned by the
+// golden files in testdata/, and `scorecard describe` derives its schemas from
+// them (see jsonSchemas in describe.go). Weekly series put completed weeks, oldest
+// first, in "weeks" and the in-progress week in "current_week"; week_ending
+// is the week's last day (or its ISO week with --iso-weeks). A "totals"
+// entry sums the completed weeks and has no week_ending.
+
+// WeekCountJSON is one week of a plain weekly count.
+type WeekCountJSON struct {
+	WeekEnding string `json:"week_ending"`
+	Count      int    `json:"count"`
+}
+
+// AllJSON is the all command's document: each report's own JSON document
+// under "data", or the error that stopped it.
+type AllJSON struct {
+	Reports []AllReportJSON `json:"reports"`
+}
+
+// AllReportJSON is one report run by the all command.
+type AllReportJSON struct {
+	Name  string          `json:"name"`
+	Title string          `json:"title"`
+	Data  json.RawMessage `json:"data,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+// ApprovalsJSON is the github approvals document.
+type ApprovalsJSON struct {
+	Repository string                  `json:"repository"`
+	Reviewers  []ApprovalsReviewerJSON `json:"reviewers"`
+}
+
+// ApprovalsReviewerJSON is one reviewer's weekly approvals.
+type ApprovalsReviewerJSON struct {
+	Reviewer    string          `json:"reviewer"`
+	Weeks       []WeekCountJSON `json:"weeks"`
+	CurrentWeek WeekCountJSON   `json:"current_week"`
+	Total       int             `json:"total"`
+}
+
+// AshbyJobJSON is one job of ashby applicants-by-week or offers-by-week: a
+// list of these is the whole document. With --by-source, source_type and
+// source replace department and job.
+type AshbyJobJSON struct {
+	Instance    string          `json:"instance,omitempty"`
+	Department  string          `json:"department,omitempty"`
+	Job         string          `json:"job,omitempty"`
+	SourceType  string          `json:"source_type,omitempty"`
+	Source      string          `json:"source,omitempty"`
+	Weeks       []WeekCountJSON `json:"weeks"`
+	CurrentWeek WeekCountJSON   `json:"current_week"`
+	Total       int             `json:"total"`
+	Average     float64         `json:"average"`
+}
+
+// FunnelJSON is the ashby funnel document. jobs is only set with --by-job.
+type FunnelJSON struct {
+	From     string            `json:"from"`
+	To       string            `json:"to"`
+	Stages   []FunnelStageJSON `json:"stages"`
+	Jobs     []FunnelJobJSON   `json:"jobs,omitempty"`
+	Archived int               `json:"archived"`
+}
+
+// FunnelJobJSON is the funnel for one job.
+type FunnelJobJSON struct {
+	Department string            `json:"department"`
+	Job        string            `json:"job"`
+	Stages     []FunnelStageJSON `json:"stages"`
+}
+
+// FunnelStageJSON is one funnel stage. conversion_pct is null for the first
+// stage or when the previous stage is empty.
+type FunnelStageJSON struct {
+	Stage         string   `json:"stage"`
+	Count         int      `json:"count"`
+	ConversionPct *float64 `json:"conversion_pct"`
+	OfAppliedPct  *float64 `json:"of_applied_pct"`
+}
+
+// OfferAcceptanceJSON is the ashby offer-acceptance document.
+type OfferAcceptanceJSON struct {
+	Weeks       []OfferAcceptanceWeekJSON `json:"weeks"`
+	CurrentWeek OfferAcceptanceWeekJSON   `json:"current_week"`
+	Totals      OfferAcceptanceWeekJSON   `json:"totals"`
+}
+
+// OfferAcceptanceWeekJSON is one week of offers. acceptance_rate is null
+// when no offers were extended.
+type OfferAcceptanceWeekJSON struct {
+	WeekEnding     string   `json:"week_ending,omitempty"`
+	Extended       int      `json:"extended"`
+	Accepted       int      `json:"accepted"`
+	AcceptanceRate *float64 `json:"acceptance_rate"`
+}
+
+// RejectionReasonJSON is one archive reason of ashby rejection-reasons: a
+// list of these is the whole document.
+type RejectionReasonJSON struct {
+	Reason      string          `json:"reason"`
+	Weeks       []WeekCountJSON `json:"weeks"`
+	CurrentWeek WeekCountJSON   `json:"current_week"`
+	Total       int             `json:"total"`
+}
+
+// CIJSON is the github ci document.
+type CIJSON struct {
+	Repository  string       `json:"repository"`
+	Workflow    string       `json:"workflow,omitempty"`
+	Weeks       []CIWeekJSON `json:"weeks"`
+	CurrentWeek CIWeekJSON   `json:"current_week"`
+	Totals      CIWeekJSON   `json:"totals"`
+}
+
+// CIWeekJSON is one week of CI runs. success_rate is null when no runs
+// completed.
+type CIWeekJSON struct {
+	WeekEnding  string   `json:"week_ending,omitempty"`
+	Success     int      `json:"success"`
+	Failure     int      `json:"failure"`
+	SuccessRate *float64 `json:"success_rate"`
+}
+
+// ActiveUsersJSON is the datum active-users document, also used for
+// active_users in export json.
+type ActiveUsersJSON struct {
+	Weeks       []ActiveUsersWeekJSON `json:"weeks"`
+	CurrentWeek ActiveUsersWeekJSON   `json:"current_week"`
+	TotalUsers  int                   `json:"total_unique_users"`
+}
+
+// ActiveUsersWeekJSON is one week of active users. verbs is only set with
+// --by-verb.
+type ActiveUsersWeekJSON struct {
+	WeekEnding  string         `json:"week_ending"`
+	ActiveUsers int            `json:"active_users"`
+	Verbs       map[string]int `json:"verbs,omitempty"`
+}
+
+// TopUserJSON is the number of write operations by one user in the datum
+// top-users document.
+type TopUserJSON struct {
+	Username   string `json:"username"`
+	Operations int    `json:"operations"`
+}
+
+// ResourceActivityJSON is one resource of datum resource-activity: a list of
+// these is the whole document.
+type ResourceActivityJSON struct {
+	Resource    string             `json:"resource"`
+	Weeks       []ResourceWeekJSON `json:"weeks"`
+	CurrentWeek ResourceWeekJSON   `json:"current_week"`
+	Total       int                `json:"total"`
+}
+
+// ResourceWeekJSON is one week of operations on a resource.
+type ResourceWeekJSON struct {
+	WeekEnding string `json:"week_ending"`
+	Operations int    `json:"operations"`
+}
+
+// DownloadsJSON is the github downloads document. delta is only set with
+// --delta.
+type DownloadsJSON struct {
+	Repository  string                 `json:"repository"`
+	GeneratedAt time.Time              `json:"generated_at"`
+	Releases    []DownloadsReleaseJSON `json:"releases"`
+	Total       int                    `json:"total"`
+	Delta       *DownloadsDeltaJSON    `json:"delta,omitempty"`
+}
+
+// DownloadsReleaseJSON is one release and its asset downloads.
+type DownloadsReleaseJSON struct {
+	Tag         string               `json:"tag"`
+	Name        string               `json:"name"`
+	PublishedAt *time.Time           `json:"published_at"`
+	Assets      []DownloadsAssetJSON `json:"assets"`
+	Downloads   int                  `json:"downloads"`
+	Change      *int                 `json:"change,omitempty"`
+}
+
+// DownloadsAssetJSON is one release asset.
+type DownloadsAssetJSON struct {
+	Name      string `json:"name"`
+	Downloads int    `json:"downloads"`
+}
+
+// DownloadsDeltaJSON compares the total with the previous snapshot.
+type DownloadsDeltaJSON struct {
+	PreviousTimestamp *time.Time `json:"previous_timestamp"`
+	PreviousTotal     *int       `json:"previous_total"`
+	Change            *int       `json:"change"`
+}
+
+// ExportJSON is the export json document. A metric that failed is missing
+// and reported in errors instead.
+type ExportJSON struct {
+	Meta        ExportMetaJSON       `json:"meta"`
+	GitHubStars *ExportStarsJSON     `json:"github_stars,omitempty"`
+	Incidents   *ExportIncidentsJSON `json:"incidents,omitempty"`
+	ActiveUsers *ActiveUsersJSON     `json:"active_users,omitempty"`
+	Errors      map[string]string    `json:"errors,omitempty"`
+}
+
+// ExportMetaJSON describes when and over which weeks an export was made.
+type ExportMetaJSON struct {
+	GeneratedAt time.Time        `json:"generated_at"`
+	Window      ExportWindowJSON `json:"window"`
+}
+
+// ExportWindowJSON is the range of weeks an export covers.
+type ExportWindowJSON struct {
+	Start       string `json:"start"`
+	End         string `json:"end"`
+	Weeks       int    `json:"weeks"`
+	CurrentWeek string `json:"current_week"`
+}
+
+// ExportStarsJSON is the github_stars section of export json.
+type ExportStarsJSON struct {
+	Owner        string                `json:"owner"`
+	Repositories []ExportStarsRepoJSON `json:"repositories"`
+	Total        int                   `json:"total"`
+}
+
+// ExportStarsRepoJSON is one repository's stars in export json.
+type ExportStarsRepoJSON struct {
+	Repository string `json:"repository"`
+	Stars      int    `json:"stars"`
+}
+
+// ExportIncidentsJSON is the incidents section of export json.
+type ExportIncidentsJSON struct {
+	Repository  string                    `json:"repository"`
+	Weeks       []ExportIncidentsWeekJSON `json:"weeks"`
+	CurrentWeek ExportIncidentsWeekJSON   `json:"current_week"`
+}
+
+// ExportIncidentsWeekJSON is one week of incidents in export json.
+type ExportIncidentsWeekJSON struct {
+	WeekEnding     string `json:"week_ending"`
+	IncidentIssue  int    `json:"incident_issue"`
+	IncidentReport int    `json:"incident_report"`
+	Total          int    `json:"total"`
+}
+
+// StarsJSON is the github stars document. totals holds the sum of each
+// extra --columns value; growth is only set with --delta.
+type StarsJSON struct {
+	Target       string           `json:"target"`
+	GeneratedAt  time.Time        `json:"generated_at"`
+	Repositories []StarsRepoJSON  `json:"repositories"`
+	Others       *StarsOthersJSON `json:"others,omitempty"`
+	Total        int              `json:"total"`
+	Totals       map[string]int   `json:"totals,omitempty"`
+	Growth       *StarsGrowthJSON `json:"growth,omitempty"`
+}
+
+// StarsRepoJSON is one repository of github stars. Optional counts are set
+// when their --columns are shown.
+type StarsRepoJSON struct {
+	Repository string   `json:"repository"`
+	Stars      int      `json:"stars"`
+	Forks      *int     `json:"forks,omitempty"`
+	Watchers   *int     `json:"watchers,omitempty"`
+	OpenIssues *int     `json:"open_issues,omitempty"`
+	Change     *int     `json:"change,omitempty"`
+	GrowthPct  *float64 `json:"growth_pct,omitempty"`
+}
+
+// StarsOthersJSON sums the repositories left out by --top.
+type StarsOthersJSON struct {
+	Count      int  `json:"count"`
+	Stars      int  `json:"stars"`
+	Forks      *int `json:"forks,omitempty"`
+	Watchers   *int `json:"watchers,omitempty"`
+	OpenIssues *int `json:"open_issues,omitempty"`
+}
+
+// StarsGrowthJSON compares the total with the previous snapshot.
+type StarsGrowthJSON struct {
+	PreviousTimestamp *time.Time `json:"previous_timestamp"`
+	PreviousTotal     *int       `json:"previous_total"`
+	GrowthPct         *float64   `json:"growth_pct"`
+}
+
+// StarsByLanguageJSON is the github stars --by-language document.
+type StarsByLanguageJSON struct {
+	Languages   []StarsLanguageJSON `json:"languages"`
+	Total       int                 `json:"total"`
+	GeneratedAt time.Time           `json:"generated_at"`
+}
+
+// StarsLanguageJSON is one language's repositories and stars. share_pct is
+// null when there are no stars at all.
+type StarsLanguageJSON struct {
+	Language     string   `json:"language"`
+	Repositories int      `json:"repositories"`
+	Stars        int      `json:"stars"`
+	SharePct     *float64 `json:"share_pct"`
+}
+
+// OverviewJSON is the github overview document.
+type OverviewJSON struct {
+	Owner        string `json:"owner"`
+	Type         string `json:"type"`
+	Repositories int    `json:"repositories"`
+	Stars        int    `json:"stars"`
+	OpenIssues   int    `json:"open_issues"`
+}
+
+// IncidentsRepoJSON is the incidents document for one repository.
+type IncidentsRepoJSON struct {
+	Repository  string              `json:"repository"`
+	Weeks       []IncidentsWeekJSON `json:"weeks"`
+	CurrentWeek IncidentsWeekJSON   `json:"current_week"`
+	Totals      IncidentsTotalsJSON `json:"totals"`
+}
+
+// IncidentsMultiJSON is the incidents document for several repositories:
+// each one on its own, and their counts combined.
+type IncidentsMultiJSON struct {
+	Repositories []IncidentsRepoJSON `json:"repositories"`
+	Combined     IncidentsRepoJSON   `json:"combined"`
+}
+
+// IncidentsWeekJSON is one week of incidents by label. status is set with
+// thresholds, weekday and weekend with --by-daytype, and the active-user
+// fields with --normalize.
+type IncidentsWeekJSON struct {
+	WeekEnding    string         `json:"week_ending"`
+	Labels        map[string]int `json:"labels"`
+	Total         int            `json:"total"`
+	Status        string         `json:"status,omitempty"`
+	Weekday       *int           `json:"weekday,omitempty"`
+	Weekend       *int           `json:"weekend,omitempty"`
+	ActiveUsers   *int           `json:"active_users,omitempty"`
+	PerActiveUser *float64       `json:"per_active_user,omitempty"`
+}
+
+// IncidentsTotalsJSON sums incidents over the completed weeks.
+type IncidentsTotalsJSON struct {
+	Labels map[string]int `json:"labels"`
+	Total  int            `json:"total"`
+}
+
+// MTTRJSON is the incidents --mttr document.
+type MTTRJSON struct {
+	Repository  string         `json:"repository"`
+	Weeks       []MTTRWeekJSON `json:"weeks"`
+	CurrentWeek MTTRWeekJSON   `json:"current_week"`
+	Totals      MTTRWeekJSON   `json:"totals"`
+}
+
+// MTTRWeekJSON is one week of incident resolution. mttr_seconds is null when
+// nothing was resolved.
+type MTTRWeekJSON struct {
+	WeekEnding  string   `json:"week_ending,omitempty"`
+	MTTRSeconds *float64 `json:"mttr_seconds"`
+	Resolved    int      `json:"resolved"`
+	StillOpen   int      `json:"still_open"`
+}
+
+// IssuesJSON is the github issues-opened-vs-closed document.
+type IssuesJSON struct {
+	Repository  string           `json:"repository"`
+	Label       string           `json:"label,omitempty"`
+	Weeks       []IssuesWeekJSON `json:"weeks"`
+	CurrentWeek IssuesWeekJSON   `json:"current_week"`
+	Totals      IssuesWeekJSON   `json:"totals"`
+}
+
+// IssuesWeekJSON is one week of issues opened and closed.
+type IssuesWeekJSON struct {
+	WeekEnding string `json:"week_ending,omitempty"`
+	Opened     int    `json:"opened"`
+	Closed     int    `json:"closed"`
+	Net        int    `json:"net"`
+}
+
+// LeadTimeJSON is the github lead-time document.
+type LeadTimeJSON struct {
+	Repository  string             `json:"repository"`
+	Source      string             `json:"source"`
+	Weeks       []LeadTimeWeekJSON `json:"weeks"`
+	CurrentWeek LeadTimeWeekJSON   `json:"current_week"`
+	Totals      LeadTimeWeekJSON   `json:"totals"`
+}
+
+// LeadTimeWeekJSON is one week of lead times. median_seconds is null when
+// there are no samples.
+type LeadTimeWeekJSON struct {
+	WeekEnding    string   `json:"week_ending,omitempty"`
+	MedianSeconds *float64 `json:"median_seconds"`
+	SampleSize    int      `json:"sample_size"`
+}
+
+// PRsJSON is the github prs document.
+type PRsJSON struct {
+	Repository  string        `json:"repository"`
+	States      []string      `json:"states"`
+	Weeks       []PRsWeekJSON `json:"weeks"`
+	CurrentWeek PRsWeekJSON   `json:"current_week"`
+	Totals      PRsWeekJSON   `json:"totals"`
+}
+
+// PRsWeekJSON is one week of pull requests, counted per requested state.
+type PRsWeekJSON struct {
+	WeekEnding string         `json:"week_ending,omitempty"`
+	Counts     map[string]int `json:"counts"`
+}
+
+// ReportJSON is the report document: one row per source that succeeded.
+type ReportJSON struct {
+	Rows []ReportRowJSON `json:"rows"`
+}
+
+// ReportRowJSON is one metric of the report document.
+type ReportRowJSON struct {
+	Metric      string          `json:"metric"`
+	Weeks       []WeekCountJSON `json:"weeks"`
+	CurrentWeek WeekCountJSON   `json:"current_week"`
+	Total       int             `json:"total"`
+}
+
+// ScorecardJSON is the github scorecard document.
+type ScorecardJSON struct {
+	Owner        string              `json:"owner"`
+	GeneratedAt  time.Time           `json:"generated_at"`
+	Repositories []ScorecardRepoJSON `json:"repositories"`
+}
+
+// ScorecardRepoJSON is one repository's health figures.
+type ScorecardRepoJSON struct {
+	Repository   string     `json:"repository"`
+	Stars        int        `json:"stars"`
+	OpenIssues   int        `json:"open_issues"`
+	OpenPulls    int        `json:"open_pull_requests"`
+	PushedAt     *time.Time `json:"pushed_at"`
+	LastPushDays *int       `json:"last_push_days"`
+}
+
+// WeeksJSON is the weeks document.
+type WeeksJSON struct {
+	Weeks       []WeeksWeekJSON `json:"weeks"`
+	CurrentWeek WeeksWeekJSON   `json:"current_week"`
+}
+
+// WeeksWeekJSON is one week's boundaries and table label.
+type WeeksWeekJSON struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+	Label string `json:"label"`
+}
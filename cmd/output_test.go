@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/")
+
+// captureOutput runs fn with stdout redirected to a buffer and the given
+// --output format, and returns what it wrote.
+func captureOutput(t *testing.T, format string, fn func() error) string {
+	t.Helper()
+	prevOut, prevFormat, prevFields := stdout, outputFormat, jsonFields
+	t.Cleanup(func() {
+		stdout, outputFormat, jsonFields = prevOut, prevFormat, prevFields
+	})
+	var buf bytes.Buffer
+	stdout, outputFormat, jsonFields = &buf, format, ""
+	if err := fn(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+// TestJSONOutputGolden pins the exact bytes of each command's JSON document.
+// Run `go test ./cmd -update` after an intended output change.
+func TestJSONOutputGolden(t *testing.T) {
+	// Wednesday Jan 7, 2026: completed weeks begin Dec 22 and Dec 29.
+	setClock(t, time.Date(2026, 1, 7, 12, 0, 0, 0, time.UTC), time.Monday, time.UTC)
+	weeks := getLastNWeeks(2)
+	current := getCurrentWeekStart()
+
+	tests := []struct {
+		name   string
+		format string
+		print  func() error
+	}{
+		{"weeks", "json", func() error {
+			return printWeeksJSON(weeks, current)
+		}},
+		{"ashby_applicants", "json", func() error {
+			return printJSONGrouped(map[string]*ashbyJobMetrics{
+				"2": {Department: "Engineering", Title: "SRE", WeekCounts: map[string]int{"2025-12-22": 3, current: 1}},
+				"1": {Department: "Engineering", Title: "Backend", WeekCounts: map[string]int{"2025-12-29": 2}},
+			}, weeks, false)
+		}},
+		{"ashby_applicants_by_source", "jsonl", func() error {
+			return printJSONGrouped(map[string]*ashbyJobMetrics{
+				"x": {Source: "LinkedIn", SourceType: "Inbound", WeekCounts: map[string]int{"2025-12-22": 4}},
+			}, weeks, true)
+		}},
+		{"ci", "json", func() error {
+			return printCIJSON("o/r", "build", weeks, map[string]*weeklyCIResults{
+				"2025-12-22": {Success: 9, Failure: 1},
+				"2025-12-29": {},
+				current:      {Success: 2},
+			}, current, weeklyCIResults{Success: 9, Failure: 1})
+		}},
+		{"issues", "json", func() error {
+			return printIssuesJSON("o/r", "bug", weeks, current,
+				map[string]int{"2025-12-22": 5, current: 1},
+				map[string]int{"2025-12-29": 2})
+		}},
+		{"prs", "json", func() error {
+			return printPRsJSON("o/r", prStates, map[string]map[string]int{
+				"open":   {"2025-12-22": 3},
+				"merged": {"2025-12-29": 2, current: 1},
+			}, weeks, current)
+		}},
+		{"offer_acceptance", "json", func() error {
+			return printOfferAcceptanceJSON(weeks, map[string]*weeklyOfferCounts{
+				"2025-12-22": {Extended: 2, Accepted: 1},
+				"2025-12-29": {},
+				current:      {},
+			}, current, weeklyOfferCounts{Extended: 2, Accepted: 1})
+		}},
+		{"rejection_reasons", "json", func() error {
+			return printRejectionReasonsJSON([]string{"Skills"}, map[string]map[string]int{
+				"Skills": {"2025-12-22": 1, "2025-12-29": 3},
+			}, weeks, current)
+		}},
+		{"resource_activity", "json", func() error {
+			return printResourceActivityJSON([]string{"projects"}, map[string]map[string]int{
+				"projects": {"2025-12-29": 7, current: 2},
+			}, weeks, current)
+		}},
+		{"incidents_mttr", "json", func() error {
+			return printIncidentMTTRJSON("o/r", weeks, incidentMTTR{
+				resolved:  map[string][]time.Duration{"2025-12-22": {time.Hour, 3 * time.Hour}},
+				stillOpen: map[string]int{"2025-12-29": 1},
+			}, current, []time.Duration{time.Hour, 3 * time.Hour}, 1)
+		}},
+		{"report", "json", func() error {
+			return printReportJSON([]tableRow{
+				{label: "GitHub Stars Δ", values: map[string]int{"2025-12-22": 4, "2025-12-29": -1, current: 2}},
+				{label: "Incidents", values: map[string]int{"2025-12-29": 3}},
+			}, weeks, current)
+		}},
+		{"lead_time", "json", func() error {
+			return printLeadTimeJSON("o/r", "releases", weeks, map[string][]time.Duration{
+				"2025-12-29": {30 * time.Minute, 90 * time.Minute, 2 * time.Hour},
+			}, current, []time.Duration{30 * time.Minute, 90 * time.Minute, 2 * time.Hour})
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := captureOutput(t, tt.format, tt.print)
+			path := filepath.Join("testdata", tt.name+"."+tt.format)
+			if *update {
+				if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("%v (run go test ./cmd -update to create it)", err)
+			}
+			if got != string(want) {
+				t.Errorf("output differs from %s:\n got: %s\nwant: %s", path, got, want)
+			}
+		})
+	}
+}
+
+func TestSelectJSONFields(t *testing.T) {
+	one := 1
+	approvals := ApprovalsJSON{Repository: "o/r", Reviewers: []ApprovalsReviewerJSON{}}
+	stars := StarsJSON{Target: "o", Repositories: []StarsRepoJSON{{Repository: "o/a", Stars: 3}}, Total: 3}
+	starsWithChange := StarsJSON{Target: "o", Repositories: []StarsRepoJSON{{Repository: "o/a", Stars: 3, Change: &one}}, Total: 3}
+	prs := PRsJSON{
+		Repository: "o/r",
+		Weeks:      []PRsWeekJSON{{WeekEnding: "2026-01-04", Counts: map[string]int{"open": 2}}},
+	}
+
+	tests := []struct {
+		name    string
+		v       interface{}
+		fields  string
+		want    string
+		wantErr bool
+	}{
+		{"empty array child", approvals, "reviewers.reviewer", `{"reviewers":[]}`, false},
+		{"omitempty field missing", stars, "repositories.change", `{"repositories":[{}]}`, false},
+		{"omitempty field present", starsWithChange, "repositories.change", `{"repositories":[{"change":1}]}`, false},
+		{"omitempty pointer struct", stars, "others,total", `{"total":3}`, false},
+		{"parent then child", stars, "repositories,repositories.stars", `{"repositories":[{"repository":"o/a","stars":3}]}`, false},
+		{"child then parent", stars, "repositories.stars,repositories", `{"repositories":[{"repository":"o/a","stars":3}]}`, false},
+		{"map key from the data", prs, "weeks.counts.open", `{"weeks":[{"counts":{"open":2}}]}`, false},
+		{"list document", []TopUserJSON{}, "username", `[]`, false},
+		{"unknown field", approvals, "reviewers.name", "", true},
+		{"unknown map key", prs, "weeks.counts.closed", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectJSONFields(tt.v, tt.fields)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("selectJSONFields(%q) = %v, want an error", tt.fields, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectJSONFields(%q): %v", tt.fields, err)
+			}
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tt.want {
+				t.Errorf("selectJSONFields(%q) = %s, want %s", tt.fields, b, tt.want)
+			}
+		})
+	}
+}
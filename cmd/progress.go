@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// progressPlainInterval is how many pages pass between plain progress lines
+// when stderr is not a terminal.
+const progressPlainInterval = 10
+
+// stderrMu serializes status output so fetches running concurrently do not
+// interleave partial lines on stderr.
+var stderrMu sync.Mutex
+
+// stderrf writes a status message to stderr, safe for concurrent use.
+func stderrf(format string, a ...interface{}) {
+	stderrMu.Lock()
+	defer stderrMu.Unlock()
+	fmt.Fprintf(os.Stderr, format, a...)
+}
+
+// progressf writes an informational progress message to stderr unless
+// --quiet is set. Warnings and errors use stderrf so they always show.
+func progressf(format string, a ...interface{}) {
+	if quiet {
+		return
+	}
+	stderrf(format, a...)
+}
+
+var (
+	// verbose holds the value of the persistent -v/--verbose flag.
+	verbose bool
+
+	// quiet holds the value of the persistent --quiet flag.
+	quiet bool
+)
+
+// logger emits leveled diagnostics to stderr: per-page request details,
+// cursors, and elapsed times at debug level, shown only with --verbose.
+// High-level progress is still written with stderrf.
+var logger = slog.New(slog.NewTextHandler(stderrWriter{}, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+func init() {
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Log debug details to stderr: each API page fetched, cursors, and elapsed time per stage")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Suppress progress messages on stderr; warnings and errors still show")
+}
+
+// setupLogger lowers the logger's level to debug when --verbose is set.
+func setupLogger() {
+	if verbose {
+		logger = slog.New(slog.NewTextHandler(stderrWriter{}, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+}
+
+// stderrWriter writes to stderr under stderrMu.
+type stderrWriter struct{}
+
+func (stderrWriter) Write(p []byte) (int, error) {
+	stderrMu.Lock()
+	defer stderrMu.Unlock()
+	return os.Stderr.Write(p)
+}
+
+// fetchProgress reports pagination progress for a long fetch on stderr.
+// On a terminal a single status line is rewritten in place after every page;
+// otherwise a plain line is emitted every progressPlainInterval pages.
+//
+// With --verbose every page and the total elapsed time are also logged at
+// debug level, and the status line is never rewritten so it cannot clobber
+// those lines.
+type fetchProgress struct {
+	name    string
+	tty     bool
+	pages   int
+	records int
+	start   time.Time
+}
+
+// newFetchProgress creates a progress reporter for the named resource, e.g. "applications".
+func newFetchProgress(name string) *fetchProgress {
+	return &fetchProgress{name: name, tty: isTerminal(os.Stderr) && !verbose, start: time.Now()}
+}
+
+// page records that another page containing n records was fetched.
+func (p *fetchProgress) page(n int) {
+	p.pages++
+	p.records += n
+	logger.Debug("page fetched", "resource", p.name, "page", p.pages, "records", n, "total", p.records, "elapsed", time.Since(p.start).Round(time.Millisecond))
+	if p.tty {
+		progressf("\r\033[K%s", p.status())
+	} else if p.pages%progressPlainInterval == 0 {
+		progressf("%s\n", p.status())
+	}
+}
+
+// done finishes the progress line so following output starts on a new line.
+func (p *fetchProgress) done() {
+	logger.Debug("fetch complete", "resource", p.name, "pages", p.pages, "records", p.records, "elapsed", time.Since(p.start).Round(time.Millisecond))
+	if p.tty && p.pages > 0 {
+		progressf("\r\033[K")
+	}
+}
+
+func (p *fetchProgress) status() string {
+	return fmt.Sprintf("%s: %s fetched (page %d)", p.name, formatThousands(p.records), p.pages)
+}
+
+// formatThousands formats n with comma thousands separators, e.g. 1,200.
+func formatThousands(n int) string {
+	s := strconv.Itoa(n)
+	if n < 0 {
+		return "-" + formatThousands(-n)
+	}
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
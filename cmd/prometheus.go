@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// prometheusFile holds the value of the persistent --prometheus-file flag.
+	prometheusFile string
+
+	// prometheusWritten records that a command wrote --prometheus-file.
+	prometheusWritten bool
+)
+
+// prometheusMetric names the gauge a command's rows are exported as.
+type prometheusMetric struct {
+	name  string // metric name
+	help  string // HELP text
+	label string // label holding the row label
+	group string // label holding the row group, if any
+}
+
+// prometheusMetrics maps templateData commands to their metric. Every series
+// also carries a target label when the report has one (org or repo).
+var prometheusMetrics = map[string]prometheusMetric{
+	"ashby applicants-by-week":             {"scorecard_applicants_total", "Applications received this week.", "job", "department"},
+	"ashby applicants-by-week --by-source": {"scorecard_applicants_by_source_total", "Applications received this week, by source.", "source", "source_type"},
+	"ashby offers-by-week":                 {"scorecard_offers_total", "Offers extended this week.", "job", "department"},
+	"ashby offer-acceptance":               {"scorecard_offer_acceptance_total", "Offers extended and accepted this week.", "status", ""},
+	"ashby rejection-reasons":              {"scorecard_rejections_total", "Applications archived this week, by reason.", "reason", ""},
+	"incidents":                            {"scorecard_incidents_total", "Incidents opened this week.", "label", "repo"},
+	"datum active-users":                   {"scorecard_datum_active_users", "Distinct active users this week.", "series", "group"},
+	"datum resource-activity":              {"scorecard_datum_resource_activity_total", "Resource mutations this week.", "resource", ""},
+	"github approvals":                     {"scorecard_github_approvals_total", "Pull request approvals this week.", "reviewer", ""},
+	"github ci":                            {"scorecard_github_ci_runs_total", "Completed CI runs this week.", "result", ""},
+	"github issues-opened-vs-closed":       {"scorecard_github_issues_total", "Issues opened or closed this week.", "state", ""},
+	"github prs":                           {"scorecard_github_prs_total", "Pull requests opened, closed, or merged this week.", "state", ""},
+	"github stars":                         {"scorecard_github_stars", "Repository stargazers.", "repo", ""},
+	"github downloads":                     {"scorecard_github_downloads_total", "Release asset downloads.", "release", ""},
+	"report":                               {"scorecard_report", "Report values this week.", "source", ""},
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&prometheusFile, "prometheus-file", "", "Also write the current week's values to this file in Prometheus text format (for node_exporter's textfile collector)")
+}
+
+// writePrometheus writes d to --prometheus-file, when set, as gauges for the
+// current week. Reports without weeks (stars, downloads) export row totals.
+// The file is replaced atomically so the collector never reads a partial
+// file. While the all command runs, the metrics are collected in
+// prometheusPending instead and written once at the end.
+func (d *templateData) writePrometheus() error {
+	if prometheusFile == "" {
+		return nil
+	}
+	if prometheusPending != nil {
+		if err := d.addPrometheus(prometheusPending); err != nil {
+			return err
+		}
+		prometheusWritten = true
+		return nil
+	}
+	var e prometheusExport
+	if err := d.addPrometheus(&e); err != nil {
+		return err
+	}
+	return e.write()
+}
+
+// prometheusPending collects the metrics of every report the all command
+// runs, so that --prometheus-file holds all of them rather than the last.
+var prometheusPending *prometheusExport
+
+// prometheusExport is the content of a --prometheus-file: metric families in
+// the order they were first added. A family added again, e.g. by a second
+// report, gains samples rather than repeating its HELP and TYPE lines.
+type prometheusExport struct {
+	names    []string
+	families map[string]*prometheusFamily
+}
+
+// prometheusFamily is one metric's HELP text and samples.
+type prometheusFamily struct {
+	help    string
+	samples []string
+}
+
+// family returns the metric family name, creating it on first use.
+func (e *prometheusExport) family(name, help string) *prometheusFamily {
+	if e.families == nil {
+		e.families = make(map[string]*prometheusFamily)
+	}
+	f, ok := e.families[name]
+	if !ok {
+		f = &prometheusFamily{help: help}
+		e.families[name] = f
+		e.names = append(e.names, name)
+	}
+	return f
+}
+
+// addPrometheus adds d's current-week gauges to e.
+func (d *templateData) addPrometheus(e *prometheusExport) error {
+	metric, ok := prometheusMetrics[d.Meta.Command]
+	if !ok {
+		return fmt.Errorf("--prometheus-file is not supported by %s", d.Meta.Command)
+	}
+
+	family := e.family(metric.name, metric.help)
+	for _, row := range d.Rows {
+		labels := [][2]string{{metric.label, row.Label}}
+		if metric.group != "" && row.Group != "" {
+			labels = append(labels, [2]string{metric.group, row.Group})
+		}
+		if d.Meta.Target != "" {
+			labels = append(labels, [2]string{"target", d.Meta.Target})
+		}
+		value := row.Total
+		if d.CurrentWeek.Start != "" {
+			value = row.Current
+		}
+		family.samples = append(family.samples, fmt.Sprintf("%s{%s} %d", metric.name, formatPrometheusLabels(labels), value))
+	}
+
+	keys := make([]string, 0, len(d.Summary))
+	for key := range d.Summary {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	prefix := strings.TrimSuffix(metric.name, "_total")
+	for _, key := range keys {
+		name := prefix + "_" + key
+		f := e.family(name, "")
+		f.samples = append(f.samples, fmt.Sprintf("%s %d", name, d.Summary[key]))
+	}
+	return nil
+}
+
+// String renders e in the Prometheus text exposition format.
+func (e *prometheusExport) String() string {
+	var b strings.Builder
+	for _, name := range e.names {
+		f := e.families[name]
+		if f.help != "" {
+			fmt.Fprintf(&b, "# HELP %s %s\n", name, escapePrometheusHelp(f.help))
+		}
+		fmt.Fprintf(&b, "# TYPE %s gauge\n", name)
+		for _, sample := range f.samples {
+			b.WriteString(sample + "\n")
+		}
+	}
+	return b.String()
+}
+
+// write replaces --prometheus-file with e.
+func (e *prometheusExport) write() error {
+	if dryRun {
+		dryRunf("write %s", prometheusFile)
+	} else if err := writeFileAtomic(prometheusFile, []byte(e.String())); err != nil {
+		return err
+	}
+	prometheusWritten = true
+	return nil
+}
+
+// checkPrometheusWritten fails a command that succeeded without writing
+// --prometheus-file, since it has no metrics to export.
+func checkPrometheusWritten(cmd *cobra.Command) error {
+	if prometheusFile == "" || prometheusWritten {
+		return nil
+	}
+	return fmt.Errorf("--prometheus-file is not supported by %s", cmd.CommandPath())
+}
+
+// formatPrometheusLabels renders name/value pairs as the inside of a label
+// set, e.g. `job="Engineer",department="R&D"`.
+func formatPrometheusLabels(labels [][2]string) string {
+	parts := make([]string, len(labels))
+	for i, l := range labels {
+		parts[i] = fmt.Sprintf("%s=\"%s\"", l[0], escapePrometheusLabel(l[1]))
+	}
+	return strings.Join(parts, ",")
+}
+
+// escapePrometheusLabel escapes a label value per the exposition format:
+// backslash, double quote, and line feed.
+func escapePrometheusLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// escapePrometheusHelp escapes HELP text, where only backslash and line feed
+// are special.
+func escapePrometheusHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
+
+// writeFileAtomic writes data to a temporary file beside path and renames it
+// into place.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Chmod(0o644); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
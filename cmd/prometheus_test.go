@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPrometheusPendingCombinesReports(t *testing.T) {
+	prevFile, prevPending, prevWritten := prometheusFile, prometheusPending, prometheusWritten
+	t.Cleanup(func() {
+		prometheusFile, prometheusPending, prometheusWritten = prevFile, prevPending, prevWritten
+	})
+	prometheusFile = filepath.Join(t.TempDir(), "scorecard.prom")
+	prometheusPending = &prometheusExport{}
+
+	current := templateWeek{Start: "2026-01-05"}
+	reports := []*templateData{
+		{Meta: templateMeta{Command: "incidents", Target: "o/a"}, CurrentWeek: current, Rows: []templateRow{{Label: "bug", Current: 2}}},
+		{Meta: templateMeta{Command: "github stars", Target: "o"}, Rows: []templateRow{{Label: "o/a", Total: 40}}},
+		{Meta: templateMeta{Command: "incidents", Target: "o/b"}, CurrentWeek: current, Rows: []templateRow{{Label: "bug", Current: 1}}},
+	}
+	for _, d := range reports {
+		if err := d.writePrometheus(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(prometheusFile); !os.IsNotExist(err) {
+		t.Fatalf("file written before the last report: %v", err)
+	}
+	if err := prometheusPending.write(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(prometheusFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `# HELP scorecard_incidents_total Incidents opened this week.
+# TYPE scorecard_incidents_total gauge
+scorecard_incidents_total{label="bug",target="o/a"} 2
+scorecard_incidents_total{label="bug",target="o/b"} 1
+# HELP scorecard_github_stars Repository stargazers.
+# TYPE scorecard_github_stars gauge
+scorecard_github_stars{repo="o/a",target="o"} 40
+`
+	if string(got) != want {
+		t.Errorf("prometheus file:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestEscapePrometheusLabel(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain", "plain"},
+		{`say "hi"`, `say \"hi\"`},
+		{`C:\path`, `C:\\path`},
+		{"two\nlines", `two\nlines`},
+		{`\"` + "\n", `\\\"\n`},
+		{"R&D, {braces} = ok", "R&D, {braces} = ok"},
+	}
+	for _, tt := range tests {
+		if got := escapePrometheusLabel(tt.in); got != tt.want {
+			t.Errorf("escapePrometheusLabel(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	got := formatPrometheusLabels([][2]string{{"job", `Sr. "Staff" Engineer`}, {"department", "R&D"}})
+	if want := `job="Sr. \"Staff\" Engineer",department="R&D"`; got != want {
+		t.Errorf("formatPrometheusLabels() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var prsCmd = &cobra.Command{
+	Use:   "prs [org]/[repo]",
+	Short: "Display pull request throughput by week for a repository",
+	Long: `Query pull requests for a repository and count them by week.
+
+--state chooses what is counted, as a comma-separated list with one row each:
+  merged  pull requests merged, by merge week (default)
+  open    pull requests opened, by creation week
+  closed  pull requests closed without merging, by close week
+
+For example, --state open,merged compares pull requests opened and merged
+each week.
+
+Displays counts for the last 4 completed weeks, or as many as weeks in the
+config file sets.
+
+Requires GITHUB_TOKEN (or github.token in the config file) for API authentication.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPRs,
+}
+
+func init() {
+	githubCmd.AddCommand(prsCmd)
+	prsCmd.Flags().Bool("json", false, "Output in JSON format")
+	prsCmd.Flags().String("state", "merged", "Comma-separated pull request events to count: open, closed, merged")
+}
+
+// prState is a pull request event that --state can count.
+type prState struct {
+	name  string // value accepted by --state
+	label string // row label
+	when  func(githubPull) *time.Time
+}
+
+var prStates = []prState{
+	{"open", "Opened", func(pr githubPull) *time.Time { return &pr.CreatedAt }},
+	{"closed", "Closed", func(pr githubPull) *time.Time {
+		if pr.MergedAt != nil {
+			return nil
+		}
+		return pr.ClosedAt
+	}},
+	{"merged", "Merged", func(pr githubPull) *time.Time { return pr.MergedAt }},
+}
+
+// parsePRStates resolves a comma-separated --state value, keeping the order
+// given.
+func parsePRStates(spec string) ([]prState, error) {
+	var states []prState
+	var names []string
+	for _, s := range prStates {
+		names = append(names, s.name)
+	}
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		found := false
+		for _, s := range prStates {
+			if s.name == name {
+				states = append(states, s)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown state %q (must be one of %s)", name, strings.Join(names, ", "))
+		}
+	}
+	return states, nil
+}
+
+func runPRs(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	args, err := argsOrConfig(args, "github.repo", "an org/repo")
+	if err != nil {
+		return err
+	}
+	repo := args[0]
+	outputJSON := jsonOutput()
+	stateSpec, _ := cmd.Flags().GetString("state")
+	states, err := parsePRStates(stateSpec)
+	if err != nil {
+		return err
+	}
+
+	token := githubToken()
+	if token == "" {
+		return errNoGitHubToken
+	}
+
+	weeks := getLastNWeeks(defaultWeekCount())
+	currentWeek := getCurrentWeekStart()
+	since, _ := parseWeekStart(weeks[0])
+
+	// Closed and merged pull requests are all in the "closed" list; only
+	// counting opened ones needs the open list too
+	listState := "closed"
+	for _, s := range states {
+		if s.name == "open" {
+			listState = "all"
+		}
+	}
+
+	progressf("Fetching pull requests for %s...\n", repo)
+	pulls, err := fetchPullsUpdatedSince(ctx, token, repo, listState, since)
+	if err != nil {
+		return fmt.Errorf("failed to fetch pull requests: %w", err)
+	}
+
+	// Any event in the window also updated the pull request, so every one
+	// is in pulls
+	counts := make(map[string]map[string]int)
+	for _, s := range states {
+		counts[s.name] = make(map[string]int)
+		for _, pr := range pulls {
+			if t := s.when(pr); t != nil && !t.Before(since) {
+				counts[s.name][getWeekStart(*t)]++
+			}
+		}
+	}
+
+	if wantTemplateData() {
+		data := newTemplateData("github prs", repo, weeks, currentWeek)
+		for _, s := range states {
+			data.addRow(s.label, "", counts[s.name])
+		}
+		if err := data.output(); err != nil || outputFormat == "template" {
+			return err
+		}
+	}
+
+	if outputJSON {
+		return printPRsJSON(repo, states, counts, weeks, currentWeek)
+	}
+
+	printTableTitle("Pull Requests for %s (%s)", repo, describeWeeks(weeks))
+
+	table := newWeeklyTable(20, 10, weeks)
+	table.printHeader("State", currentWeek)
+	table.printSeparator(currentWeek)
+	for _, s := range states {
+		table.printRow(s.label, counts[s.name], currentWeek)
+	}
+
+	return nil
+}
+
+func printPRsJSON(repo string, states []prState, counts map[string]map[string]int, weeks []string, currentWeek string) error {
+	output := PRsJSON{Repository: repo, Totals: PRsWeekJSON{Counts: make(map[string]int)}}
+	weekData := func(week string) PRsWeekJSON {
+		data := PRsWeekJSON{WeekEnding: weekStartToEnd(week), Counts: make(map[string]int)}
+		for _, s := range states {
+			data.Counts[s.name] = counts[s.name][week]
+		}
+		return data
+	}
+	for _, s := range states {
+		output.States = append(output.States, s.name)
+	}
+	for _, week := range weeks {
+		data := weekData(week)
+		output.Weeks = append(output.Weeks, data)
+		for name, count := range data.Counts {
+			output.Totals.Counts[name] += count
+		}
+	}
+	output.CurrentWeek = weekData(currentWeek)
+
+	return printJSON(output)
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"io"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+// rawOutput, when non-nil, receives every unprocessed API response body as it
+// is fetched. It is enabled by the --raw flag on commands that support it.
+var rawOutput io.Writer
+
+// rawMu keeps concurrently fetched responses from interleaving.
+var rawMu sync.Mutex
+
+// enableRawOutput turns on raw response dumping to stdout if the command's
+// --raw flag is set, and reports whether it did.
+func enableRawOutput(cmd *cobra.Command) bool {
+	raw, _ := cmd.Flags().GetBool("raw")
+	if raw {
+		rawOutput = stdout
+	}
+	return raw
+}
+
+// writeRaw writes an API response body to rawOutput, one response per line.
+func writeRaw(body []byte) {
+	if rawOutput == nil {
+		return
+	}
+	rawMu.Lock()
+	defer rawMu.Unlock()
+	rawOutput.Write(body)
+	if len(body) == 0 || body[len(body)-1] != '\n' {
+		rawOutput.Write([]byte("\n"))
+	}
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Display metrics from several sources in one weekly table",
+	Long: `Combine weekly metrics from several sources into a single table that shares
+the same week columns.
+
+Rows are included for each source that is requested:
+  --org     GitHub Stars Δ, derived from the stars snapshot history
+            (record snapshots with 'scorecard github stars --snapshot')
+  --repo    Incidents for a GitHub repository (requires GITHUB_TOKEN)
+  --datum   Datum Cloud active users (requires an authenticated datumctl)
+
+If a source fails, its row is omitted and the error is printed to stderr.
+
+Displays counts for the last 4 completed weeks, or as many as weeks in the
+config file sets.`,
+	RunE: runReport,
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	addReportSourceFlags(reportCmd)
+}
+
+// reportSources selects which metric sources the combined commands include.
+type reportSources struct {
+	Org          string
+	SnapshotFile string
+	Repo         string
+	Datum        bool
+}
+
+// addReportSourceFlags registers the source selection flags shared by the combined commands.
+func addReportSourceFlags(cmd *cobra.Command) {
+	cmd.Flags().String("org", "", "GitHub org or user whose stars to include")
+	cmd.Flags().String("snapshot-file", "", "Path to the star snapshot history (default: <user config dir>/scorecard/stars.json)")
+	cmd.Flags().String("repo", "", "GitHub repository (org/repo) whose incidents to include")
+	cmd.Flags().Bool("datum", false, "Include Datum Cloud active users")
+}
+
+// getReportSources reads the source selection flags, requiring at least one source.
+func getReportSources(cmd *cobra.Command) (reportSources, error) {
+	var sources reportSources
+	sources.Org, _ = cmd.Flags().GetString("org")
+	sources.SnapshotFile, _ = cmd.Flags().GetString("snapshot-file")
+	sources.Repo, _ = cmd.Flags().GetString("repo")
+	sources.Datum, _ = cmd.Flags().GetBool("datum")
+
+	// Fall back to the config file for sources not given as flags
+	if !cmd.Flags().Changed("org") {
+		sources.Org = config.GetString("github.org")
+	}
+	if !cmd.Flags().Changed("repo") {
+		sources.Repo = config.GetString("github.repo")
+	}
+	if !cmd.Flags().Changed("datum") {
+		sources.Datum = config.GetBool("datum.enabled")
+	}
+
+	if sources.Org == "" && sources.Repo == "" && !sources.Datum {
+		return sources, fmt.Errorf("nothing to report: specify at least one of --org, --repo, or --datum (or github.org, github.repo, or datum.enabled in the config file)")
+	}
+	return sources, nil
+}
+
+func runReport(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	sources, err := getReportSources(cmd)
+	if err != nil {
+		return err
+	}
+
+	weeks := getLastNWeeks(defaultWeekCount())
+	currentWeek := getCurrentWeekStart()
+	builder := newTableBuilder(newWeeklyTable(20, 10, weeks), currentWeek)
+	failed := 0
+
+	if sources.Org != "" {
+		changes, err := reportStarChanges(ctx, sources.Org, sources.SnapshotFile, weeks, currentWeek)
+		if err != nil {
+			stderrf("GitHub Stars Δ: %v\n", err)
+			failed++
+		} else {
+			builder.addRow("GitHub Stars Δ", changes)
+		}
+	}
+
+	if sources.Repo != "" {
+		incidents, err := reportIncidents(ctx, sources.Repo, weeks, currentWeek)
+		if err != nil {
+			stderrf("Incidents: %v\n", err)
+			failed++
+		} else {
+			builder.addRow("Incidents", incidents)
+		}
+	}
+
+	if sources.Datum {
+		activeUsers, err := reportActiveUsers(ctx, weeks, currentWeek)
+		if err != nil {
+			stderrf("Active Users: %v\n", err)
+			failed++
+		} else {
+			builder.addRow("Active Users", activeUsers)
+		}
+	}
+
+	if len(builder.rows) == 0 {
+		return fmt.Errorf("all %d sources failed", failed)
+	}
+
+	if wantTemplateData() {
+		data := newTemplateData("report", "", weeks, currentWeek)
+		for _, row := range builder.rows {
+			data.addRow(row.label, "", row.values)
+		}
+		if err := data.output(); err != nil || outputFormat == "template" {
+			return err
+		}
+	}
+
+	if jsonOutput() {
+		return printReportJSON(builder.rows, weeks, currentWeek)
+	}
+
+	builder.render("Metric")
+	return nil
+}
+
+// printReportJSON prints the report rows with their weekly counts.
+func printReportJSON(rows []tableRow, weeks []string, currentWeek string) error {
+	output := ReportJSON{Rows: []ReportRowJSON{}}
+	for _, row := range rows {
+		data := ReportRowJSON{
+			Metric:      row.label,
+			CurrentWeek: WeekCountJSON{WeekEnding: weekStartToEnd(currentWeek), Count: row.values[currentWeek]},
+		}
+		for _, week := range weeks {
+			data.Weeks = append(data.Weeks, WeekCountJSON{WeekEnding: weekStartToEnd(week), Count: row.values[week]})
+			data.Total += row.values[week]
+		}
+		output.Rows = append(output.Rows, data)
+	}
+	return printJSONList(output.Rows, output)
+}
+
+// reportStarChanges returns the weekly change in total stars for org from the snapshot history.
+func reportStarChanges(ctx context.Context, org, snapshotFile string, weeks []string, currentWeek string) (map[string]int, error) {
+	if snapshotFile == "" {
+		var err error
+		snapshotFile, err = defaultSnapshotFile("stars.json")
+		if err != nil {
+			return nil, err
+		}
+	}
+	history, err := loadStarHistory(snapshotFile)
+	if err != nil {
+		return nil, err
+	}
+	snaps := history[org]
+	if len(snaps) == 0 {
+		return nil, fmt.Errorf("no snapshots recorded for %s", org)
+	}
+	return weeklyStarChanges(snaps, append(append([]string{}, weeks...), currentWeek)), nil
+}
+
+// reportIncidents returns the total incident count per week for repo.
+func reportIncidents(ctx context.Context, repo string, weeks []string, currentWeek string) (map[string]int, error) {
+	token := githubToken()
+	if token == "" {
+		return nil, errNoGitHubToken
+	}
+
+	progressf("Fetching incidents for %s...\n", repo)
+	counts, currentCounts, err := countIncidentsByWeek(ctx, token, repo, defaultIncidentLabels, weeks, currentWeek, false)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]int)
+	for _, c := range counts {
+		totals[c.WeekStart] = c.total()
+	}
+	totals[currentWeek] = currentCounts.total()
+	return totals, nil
+}
+
+// reportActiveUsers returns the number of unique Datum Cloud active users per week.
+func reportActiveUsers(ctx context.Context, weeks []string, currentWeek string) (map[string]int, error) {
+	datumctl, err := findDatumctl()
+	if err != nil {
+		return nil, err
+	}
+
+	progressf("Querying Datum Cloud audit logs for the %s...\n", strings.ToLower(describeWeeks(weeks)))
+	weekCounts, _, err := countActiveUsersByWeek(ctx, datumctl, 0, weeks, currentWeek)
+	return weekCounts, err
+}
@@ -1,8 +1,12 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/spf13/cobra"
 )
@@ -11,10 +15,122 @@ var rootCmd = &cobra.Command{
 	Use:   "scorecard",
 	Short: "A CLI tool for various metrics and reporting",
 	Long:  "Scorecard is a CLI tool for pulling metrics from various sources and generating reports.",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		setupLogger()
+		if _, err := loadConfig(); err != nil {
+			return err
+		}
+		if err := validateColorMode(); err != nil {
+			return err
+		}
+		if err := validateHTTPFlags(); err != nil {
+			return err
+		}
+		if err := resolveTimezone(); err != nil {
+			return err
+		}
+		if err := validateWeekStart(); err != nil {
+			return err
+		}
+		if err := validateDateFormat(); err != nil {
+			return err
+		}
+		if err := validateZeroStyle(); err != nil {
+			return err
+		}
+		if err := resolveAshbyAPIBase(); err != nil {
+			return err
+		}
+		if err := resolveOutputAliases(cmd); err != nil {
+			return err
+		}
+		if err := openOutputFile(); err != nil {
+			return err
+		}
+		if err := startSlackCapture(); err != nil {
+			return err
+		}
+		switch outputFormat {
+		case "table", "json", "jsonl", "csv", "tsv", "markdown":
+		case "template":
+			return loadOutputTemplate(templateFile)
+		default:
+			return fmt.Errorf("invalid --output value %q (must be table, json, jsonl, csv, tsv, markdown, or template)", outputFormat)
+		}
+		return nil
+	},
+}
+
+// outputAliases maps the per-command format flags that predate --output to
+// the format they select.
+var outputAliases = map[string]string{"json": "json", "csv": "csv"}
+
+// resolveOutputAliases applies a deprecated --json or --csv flag to --output.
+func resolveOutputAliases(cmd *cobra.Command) error {
+	for name, format := range outputAliases {
+		f := cmd.Flags().Lookup(name)
+		if f == nil || !f.Changed || f.Value.String() != "true" {
+			continue
+		}
+		if cmd.Flags().Changed("output") && outputFormat != format {
+			return fmt.Errorf("--%s conflicts with --output %s", name, outputFormat)
+		}
+		outputFormat = format
+	}
+	return nil
+}
+
+// deprecateOutputAliases marks every command's --json and --csv flags as
+// deprecated in favor of --output.
+func deprecateOutputAliases(cmd *cobra.Command) {
+	for name, format := range outputAliases {
+		if cmd.LocalNonPersistentFlags().Lookup(name) != nil {
+			cmd.Flags().MarkDeprecated(name, "use --output "+format+" instead")
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		deprecateOutputAliases(sub)
+	}
+}
+
+var (
+	outputFormat string
+	templateFile string
+)
+
+func init() {
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "Output format: table, json, jsonl, csv, tsv, markdown, or template")
+	rootCmd.PersistentFlags().StringVar(&templateFile, "template-file", "", "Go text/template file used with --output template")
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	deprecateOutputAliases(rootCmd)
+
+	// Ctrl-C or SIGTERM cancels every in-flight request through cmd.Context()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cmd, err := rootCmd.ExecuteContextC(ctx)
+	if errors.Is(err, context.Canceled) {
+		err = errors.New("canceled")
+	}
+	if err == nil {
+		err = checkPrometheusWritten(cmd)
+	}
+	if err == nil {
+		err = checkTemplateRendered(cmd)
+	}
+	if cerr := closeOutputFile(); cerr != nil && err == nil {
+		err = fmt.Errorf("failed to write output file: %w", cerr)
+	}
+	// Post whatever was captured even if a report failed, so that all still
+	// shares the sections that succeeded; both errors are returned
+	if ctx.Err() == nil {
+		if serr := postSlackReport(ctx); serr != nil {
+			err = errors.Join(err, serr)
+		}
+	}
+	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
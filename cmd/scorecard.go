@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var scorecardCmd = &cobra.Command{
+	Use:   "scorecard [org-or-user]",
+	Short: "Display stars, open issues, open PRs, and last push for every repository",
+	Long: `Fetch all repositories for a GitHub organization or user and print one row
+per repository with its stars, open issues, open pull requests, and the time
+since its last push.
+
+GitHub's open_issues_count includes pull requests, so open pull requests are
+counted separately and subtracted to give the open issue count. This costs one
+extra API call per repository (more for repositories with over 100 open PRs).
+
+Use --sort-by to order rows by stars (default), issues, or activity (most
+recently pushed first), and --top N to show only the first N rows.
+
+Requires GITHUB_TOKEN (or github.token in the config file) for API authentication.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runScorecard,
+}
+
+func init() {
+	githubCmd.AddCommand(scorecardCmd)
+	scorecardCmd.Flags().Bool("json", false, "Output in JSON format")
+	scorecardCmd.Flags().String("sort-by", "stars", "Sort rows by stars, issues, or activity")
+	scorecardCmd.Flags().Int("top", 0, "Only show the first N repositories (0 = all)")
+}
+
+// repoScore is one row of the GitHub scorecard.
+type repoScore struct {
+	Name       string
+	Stars      int
+	OpenIssues int
+	OpenPulls  int
+	PushedAt   time.Time
+}
+
+func runScorecard(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	args, err := argsOrConfig(args, "github.org", "an org or user")
+	if err != nil {
+		return err
+	}
+	owner := args[0]
+	outputJSON := jsonOutput()
+	sortBy, _ := cmd.Flags().GetString("sort-by")
+	top, _ := cmd.Flags().GetInt("top")
+
+	if sortBy != "stars" && sortBy != "issues" && sortBy != "activity" {
+		return fmt.Errorf("invalid --sort-by %q (must be stars, issues, or activity)", sortBy)
+	}
+	if top < 0 {
+		return fmt.Errorf("--top must not be negative")
+	}
+
+	token := githubToken()
+	if token == "" {
+		return errNoGitHubToken
+	}
+
+	progressf("Fetching repositories for %s...\n", owner)
+	repos, err := fetchOwnerRepos(ctx, token, owner)
+	if err != nil {
+		return err
+	}
+	if len(repos) == 0 {
+		if dryRun {
+			return nil
+		}
+		return fmt.Errorf("no repositories found for '%s'", owner)
+	}
+
+	progressf("Counting open pull requests for %d repositories...\n", len(repos))
+	openPulls, err := countOpenPulls(ctx, token, repos)
+	if err != nil {
+		return fmt.Errorf("failed to count open pull requests: %w", err)
+	}
+
+	scores := make([]repoScore, 0, len(repos))
+	for _, repo := range repos {
+		pulls := openPulls[repo.FullName]
+		scores = append(scores, repoScore{
+			Name:       repo.Name,
+			Stars:      repo.StargazersCount,
+			OpenIssues: repo.OpenIssuesCount - pulls,
+			OpenPulls:  pulls,
+			PushedAt:   repo.PushedAt,
+		})
+	}
+
+	sort.Slice(scores, func(i, j int) bool {
+		a, b := scores[i], scores[j]
+		switch sortBy {
+		case "issues":
+			if a.OpenIssues != b.OpenIssues {
+				return a.OpenIssues > b.OpenIssues
+			}
+		case "activity":
+			if !a.PushedAt.Equal(b.PushedAt) {
+				return a.PushedAt.After(b.PushedAt)
+			}
+		default:
+			if a.Stars != b.Stars {
+				return a.Stars > b.Stars
+			}
+		}
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	})
+	if top > 0 && top < len(scores) {
+		scores = scores[:top]
+	}
+
+	now := timeNow().UTC()
+
+	if outputJSON {
+		return printScorecardJSON(owner, scores, now)
+	}
+
+	if gridFormat() {
+		var rows [][]string
+		for _, s := range scores {
+			lastPush := ""
+			if !s.PushedAt.IsZero() {
+				lastPush = s.PushedAt.UTC().Format(time.RFC3339)
+			}
+			rows = append(rows, []string{s.Name, strconv.Itoa(s.Stars), strconv.Itoa(s.OpenIssues), strconv.Itoa(s.OpenPulls), lastPush})
+		}
+		printGrid([]string{"Repository", "Stars", "Issues", "PRs", "Last Push"}, rows)
+		return nil
+	}
+
+	fmt.Fprintf(stdout, "%-40s %10s %10s %10s %12s\n", "Repository", "Stars", "Issues", "PRs", "Last Push")
+	fmt.Fprintln(stdout, strings.Repeat("=", 86))
+	for _, s := range scores {
+		lastPush := "-"
+		if !s.PushedAt.IsZero() {
+			lastPush = humanizeDuration(now.Sub(s.PushedAt))
+		}
+		fmt.Fprintf(stdout, "%-40s %10d %10d %10d %12s\n", s.Name, s.Stars, s.OpenIssues, s.OpenPulls, lastPush)
+	}
+
+	return nil
+}
+
+// countOpenPulls returns the number of open pull requests in each repository,
+// keyed by full name (org/repo).
+func countOpenPulls(ctx context.Context, token string, repos []githubRepo) (map[string]int, error) {
+	counts := make(map[string]int)
+	progress := newFetchProgress("open pull requests")
+	defer progress.done()
+
+	client := newHTTPClient()
+
+	for _, repo := range repos {
+		// Follow the Link header until there is no next page
+		next := fmt.Sprintf("https://api.github.com/repos/%s/pulls?state=open&per_page=100", repo.FullName)
+		for next != "" {
+			body, nextURL, err := githubRequestPage(ctx, client, token, next)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", repo.FullName, err)
+			}
+
+			var pulls []githubPull
+			if err := json.Unmarshal(body, &pulls); err != nil {
+				return nil, err
+			}
+			writeRaw(body)
+			progress.page(len(pulls))
+
+			counts[repo.FullName] += len(pulls)
+			next = nextURL
+		}
+	}
+
+	return counts, nil
+}
+
+func printScorecardJSON(owner string, scores []repoScore, generated time.Time) error {
+	output := ScorecardJSON{Owner: owner, GeneratedAt: generated, Repositories: []ScorecardRepoJSON{}}
+	for _, s := range scores {
+		data := ScorecardRepoJSON{Repository: s.Name, Stars: s.Stars, OpenIssues: s.OpenIssues, OpenPulls: s.OpenPulls}
+		if !s.PushedAt.IsZero() {
+			pushedAt := s.PushedAt
+			days := int(generated.Sub(pushedAt).Hours() / 24)
+			data.PushedAt = &pushedAt
+			data.LastPushDays = &days
+		}
+		output.Repositories = append(output.Repositories, data)
+	}
+
+	return printJSON(output)
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// slackWebhook holds the value of the persistent --slack-webhook flag.
+	slackWebhook string
+
+	// slackOnly suppresses stdout when the report is posted to Slack.
+	slackOnly bool
+
+	// slackReport captures the report body while --slack-webhook is set.
+	slackReport *bytes.Buffer
+)
+
+// slackMaxText is the longest message text Slack accepts before truncating.
+const slackMaxText = 40000
+
+// ansiEscape matches the SGR sequences written by --color always.
+var ansiEscape = regexp.MustCompile("\033\\[[0-9;]*m")
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&slackWebhook, "slack-webhook", "", "Post the report to this Slack incoming webhook URL (default $SLACK_WEBHOOK_URL)")
+	rootCmd.PersistentFlags().BoolVar(&slackOnly, "slack-only", false, "With --slack-webhook, post the report without writing it to stdout")
+}
+
+// startSlackCapture resolves the webhook URL and, when one is set, tees the
+// report body into slackReport. With --slack-only the report goes to Slack
+// alone.
+func startSlackCapture() error {
+	if slackWebhook == "" {
+		slackWebhook = envOrConfig("SLACK_WEBHOOK_URL")
+	}
+	if slackWebhook == "" {
+		if slackOnly {
+			return fmt.Errorf("--slack-only requires --slack-webhook or SLACK_WEBHOOK_URL")
+		}
+		return nil
+	}
+	if u, err := url.Parse(slackWebhook); err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("invalid Slack webhook URL %q (must be an https URL)", redactURL(slackWebhook))
+	}
+	if slackOnly && outputFile != "" {
+		return fmt.Errorf("--slack-only conflicts with --output-file")
+	}
+
+	slackReport = &bytes.Buffer{}
+	if slackOnly {
+		stdout = slackReport
+	} else {
+		stdout = io.MultiWriter(stdout, slackReport)
+	}
+	return nil
+}
+
+// postSlackReport posts the captured report to the Slack webhook as a code
+// block, so tables keep their alignment. An empty report is not posted.
+func postSlackReport(ctx context.Context) error {
+	if slackReport == nil {
+		return nil
+	}
+	text := strings.TrimRight(ansiEscape.ReplaceAllString(slackReport.String(), ""), "\n")
+	if text == "" {
+		return nil
+	}
+	// Leave room for the fences and the truncation note
+	if len(text) > slackMaxText-100 {
+		text = truncateUTF8(text, slackMaxText-100) + "\n… (truncated)"
+	}
+
+	body, err := json.Marshal(map[string]string{"text": "```\n" + text + "\n```"})
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		dryRunf("POST %s %s", redactURL(slackWebhook), body)
+		return nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, slackWebhook, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create Slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := newSingleAttemptHTTPClient().Do(req)
+	if err != nil {
+		// The error quotes the URL, which is the webhook's secret
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("failed to post to Slack: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// Slack explains the failure in a short plain-text body, e.g.
+		// "invalid_payload" or "no_service"
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("Slack webhook returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	progressf("Posted report to Slack\n")
+	return nil
+}
+
+// redactURL returns only the scheme and host of a webhook URL, whose path is
+// a secret.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "<redacted>"
+	}
+	return u.Scheme + "://" + u.Host + "/…"
+}
+
+// truncateUTF8 returns the longest prefix of s that is at most n bytes and
+// does not split a character.
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
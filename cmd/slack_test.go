@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncateUTF8(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"abc", 5, "abc"},
+		{"abc", 2, "ab"},
+		{"a€b", 2, "a"}, // € is three bytes
+		{"a€b", 3, "a"},
+		{"a€b", 4, "a€"},
+		{"€", 0, ""},
+	}
+	for _, tt := range tests {
+		got := truncateUTF8(tt.s, tt.n)
+		if got != tt.want || !utf8.ValidString(got) {
+			t.Errorf("truncateUTF8(%q, %d) = %q, want %q", tt.s, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	got := redactURL("https://hooks.slack.com/services/T000/B000/secret")
+	if got != "https://hooks.slack.com/…" || strings.Contains(got, "secret") {
+		t.Errorf("redactURL() = %q", got)
+	}
+	if got := redactURL("not a url"); got != "<redacted>" {
+		t.Errorf("redactURL(invalid) = %q, want <redacted>", got)
+	}
+}
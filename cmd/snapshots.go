@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// starSnapshot records the star counts observed for one target at one point in time.
+// Release download snapshots reuse the same shape, keyed by release tag.
+type starSnapshot struct {
+	Timestamp time.Time      `json:"timestamp"`
+	Repos     map[string]int `json:"repos"`
+	Total     int            `json:"total"`
+}
+
+// starHistory maps a GitHub org or user to its snapshots, oldest first.
+type starHistory map[string][]starSnapshot
+
+// defaultSnapshotFile returns the default location of a snapshot history file,
+// e.g. "stars.json", in the user's config directory.
+func defaultSnapshotFile(name string) (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine config directory: %w", err)
+	}
+	return filepath.Join(dir, "scorecard", name), nil
+}
+
+// loadStarHistory reads the snapshot history from path.
+// A missing file is treated as an empty history.
+func loadStarHistory(path string) (starHistory, error) {
+	history := make(starHistory)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot history: %w", err)
+	}
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot history %s: %w", path, err)
+	}
+	return history, nil
+}
+
+// saveStarHistory writes the snapshot history to path, creating parent directories as needed.
+func saveStarHistory(path string, history starHistory) error {
+	if dryRun {
+		dryRunf("write %s", path)
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// latest returns the most recent snapshot for target, if any.
+func (h starHistory) latest(target string) (starSnapshot, bool) {
+	snaps := h[target]
+	if len(snaps) == 0 {
+		return starSnapshot{}, false
+	}
+	return snaps[len(snaps)-1], true
+}
+
+// growthRate returns the percentage change from previous to current.
+// It reports false when there is no meaningful rate (previous is zero).
+func growthRate(current, previous int) (float64, bool) {
+	if previous == 0 {
+		return 0, false
+	}
+	rate := float64(current-previous) / float64(previous) * 100
+	return math.Round(rate*10) / 10, true
+}
+
+// formatGrowth renders a growth rate like "+3.2%", or "n/a" when unavailable.
+func formatGrowth(rate float64, ok bool) string {
+	if !ok {
+		return "n/a"
+	}
+	return fmt.Sprintf("%+.1f%%", rate)
+}
+
+// weeklyStarChanges derives the change in total stars for each of the given
+// weeks (Monday date strings) from a target's snapshots. A week's change is the
+// difference between the last snapshot taken before the week ended and the last
+// snapshot taken before it began. Weeks without snapshots on both sides are omitted.
+func weeklyStarChanges(snaps []starSnapshot, weeks []string) map[string]int {
+	changes := make(map[string]int)
+	lastBefore := func(t time.Time) (starSnapshot, bool) {
+		var found starSnapshot
+		ok := false
+		for _, snap := range snaps {
+			if snap.Timestamp.Before(t) {
+				found, ok = snap, true
+			}
+		}
+		return found, ok
+	}
+	for _, week := range weeks {
+		start, err := parseWeekStart(week)
+		if err != nil {
+			continue
+		}
+		before, ok := lastBefore(start)
+		if !ok {
+			continue
+		}
+		after, ok := lastBefore(start.AddDate(0, 0, 7))
+		if !ok {
+			continue
+		}
+		changes[week] = after.Total - before.Total
+	}
+	return changes
+}
+
+// appendStarsCSV appends one row of star counts to the CSV file at path.
+// The header is "timestamp,total" followed by one column per repository.
+// The header is written only when the file is new; repositories not seen
+// before are added as new columns at the end, and existing rows are padded
+// with empty cells so they stay valid.
+func appendStarsCSV(path string, timestamp time.Time, repos []githubRepo, total int) error {
+	if dryRun {
+		dryRunf("append to %s", path)
+		return nil
+	}
+	var rows [][]string
+	f, err := os.Open(path)
+	if err == nil {
+		rows, err = csv.NewReader(f).ReadAll()
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	header := []string{"timestamp", "total"}
+	if len(rows) > 0 {
+		header = rows[0]
+		if len(header) < 2 || header[0] != "timestamp" || header[1] != "total" {
+			return fmt.Errorf("%s does not look like a stars CSV (expected header to start with timestamp,total)", path)
+		}
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[name] = i
+	}
+	var added []string
+	for _, repo := range repos {
+		if _, ok := columns[repo.Name]; !ok {
+			added = append(added, repo.Name)
+		}
+	}
+	sort.Strings(added)
+	for _, name := range added {
+		columns[name] = len(header)
+		header = append(header, name)
+	}
+
+	row := make([]string, len(header))
+	row[0] = timestamp.UTC().Format(time.RFC3339)
+	row[1] = strconv.Itoa(total)
+	for _, repo := range repos {
+		row[columns[repo.Name]] = strconv.Itoa(repo.StargazersCount)
+	}
+
+	// Only the new row needs writing unless the header changed.
+	if len(rows) > 0 && len(added) == 0 {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return err
+		}
+		w := csv.NewWriter(f)
+		w.Write(row)
+		w.Flush()
+		if err := w.Error(); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	}
+
+	if len(rows) > 0 {
+		rows[0] = header
+	} else {
+		rows = append(rows, header)
+	}
+	rows = append(rows, row)
+	for i := range rows {
+		for len(rows[i]) < len(header) {
+			rows[i] = append(rows[i], "")
+		}
+	}
+
+	f, err = os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(f)
+	w.WriteAll(rows)
+	if err := w.Error(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
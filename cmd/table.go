@@ -1,73 +1,345 @@
 package cmd
 
 import (
+	"encoding/csv"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
+// zeroStyle holds the value of the persistent --zero flag, which controls how
+// zero counts render in tables: "dash" (default), "zero", or "blank".
+var zeroStyle string
+
+// showWoW holds the value of the persistent --wow flag, which adds a
+// week-over-week percent change column to weekly tables.
+var showWoW bool
+
+// showSparkline holds the value of the persistent --sparkline flag, which adds
+// a trend column to weekly tables.
+var showSparkline bool
+
+// sparkBlocks are the bar heights used for sparklines, lowest first.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&zeroStyle, "zero", "dash", "How zero counts render in tables: dash, zero, or blank")
+	rootCmd.PersistentFlags().BoolVar(&showWoW, "wow", false, "Add a WoW % column comparing the last completed week to the one before")
+	rootCmd.PersistentFlags().BoolVar(&showSparkline, "sparkline", false, "Add a Trend column with a sparkline of each row's weekly counts")
+}
+
+// validateZeroStyle checks that --zero holds one of the supported values.
+func validateZeroStyle() error {
+	switch zeroStyle {
+	case "dash", "zero", "blank":
+		return nil
+	}
+	return fmt.Errorf("invalid --zero value %q (must be dash, zero, or blank)", zeroStyle)
+}
+
+// zeroCell returns how a zero count is displayed according to --zero.
+func zeroCell() string {
+	switch zeroStyle {
+	case "zero":
+		return "0"
+	case "blank":
+		return ""
+	}
+	return "-"
+}
+
 // weeklyTable represents a table with weeks as columns and rows of data.
+//
+// Rows are rendered as fixed-width text by default. With --output markdown the
+// same calls produce a GitHub-flavored markdown table: week-ending dates head
+// the columns, the label column is left-aligned, separators are dropped, and
+// totals rows are bold since there is no rule above them.
 type weeklyTable struct {
 	labelColWidth int
 	weekColWidth  int
 	weeks         []string
+
+	// cellColor optionally returns an ANSI color code for a weekly cell value.
+	// It is only consulted when color output is enabled.
+	cellColor func(count int) string
+
+	// format is "csv", "tsv", or "markdown" when --output asks for that layout
+	// instead of fixed-width text.
+	format string
+
+	// showChange adds a WoW % column after Total; see --wow.
+	showChange bool
+
+	// showTrend adds a sparkline column at the end of each row; see
+	// --sparkline. CSV output never includes it.
+	showTrend bool
+
+	// autoSize tables buffer rows added with addRow and friends, and pick
+	// column widths that fit them when flush is called.
+	autoSize    bool
+	currentWeek string
+	buffered    []bufferedRow
+}
+
+// bufferedRowKind says which streaming printer replays a bufferedRow.
+type bufferedRowKind int
+
+const (
+	bufferedData bufferedRowKind = iota
+	bufferedTotals
+	bufferedSection
+	bufferedSeparator
+	bufferedText
+)
+
+// bufferedRow is a row held by an auto-sized table until flush.
+type bufferedRow struct {
+	kind   bufferedRowKind
+	label  string
+	values map[string]int
+	cells  []string // bufferedText only
 }
 
 // newWeeklyTable creates a new weekly table with the specified column widths and weeks.
 func newWeeklyTable(labelColWidth, weekColWidth int, weeks []string) *weeklyTable {
-	return &weeklyTable{
+	t := &weeklyTable{
 		labelColWidth: labelColWidth,
 		weekColWidth:  weekColWidth,
 		weeks:         weeks,
+		showChange:    showWoW,
+		showTrend:     showSparkline && !delimitedFormat(),
+	}
+	if gridFormat() {
+		t.format = outputFormat
 	}
+	// Keep a gap before each week label; --date-format may make them wider
+	for _, week := range weeks {
+		if w := utf8.RuneCountInString(formatWeekEnd(week)) + 2; w > t.weekColWidth && weekColWidth > 0 {
+			t.weekColWidth = w
+		}
+	}
+	return t
+}
+
+// newAutoWeeklyTable creates a weekly table whose column widths are computed
+// from its content. Rows are added with addRow, addTotalsRow, addTextRow,
+// addSection, and addSeparator, and nothing is printed until flush. If currentWeek is
+// non-empty, a Current column is included.
+func newAutoWeeklyTable(weeks []string, currentWeek string) *weeklyTable {
+	t := newWeeklyTable(0, 0, weeks)
+	t.autoSize = true
+	t.currentWeek = currentWeek
+	return t
+}
+
+// addRow buffers a data row. values is a map from week (Monday date string) to count.
+func (t *weeklyTable) addRow(label string, values map[string]int) {
+	t.buffered = append(t.buffered, bufferedRow{kind: bufferedData, label: label, values: values})
+}
+
+// addTotalsRow buffers a totals row; see printTotalsRow.
+func (t *weeklyTable) addTotalsRow(label string, values map[string]int) {
+	t.buffered = append(t.buffered, bufferedRow{kind: bufferedTotals, label: label, values: values})
+}
+
+// addTextRow buffers a row of preformatted cells; see printTextRow.
+func (t *weeklyTable) addTextRow(label string, cells []string) {
+	t.buffered = append(t.buffered, bufferedRow{kind: bufferedText, label: label, cells: cells})
+}
+
+// addSection buffers the start of a named group of rows; see printSection.
+func (t *weeklyTable) addSection(name string) {
+	t.buffered = append(t.buffered, bufferedRow{kind: bufferedSection, label: name})
+}
+
+// addSeparator buffers a horizontal separator line.
+func (t *weeklyTable) addSeparator() {
+	t.buffered = append(t.buffered, bufferedRow{kind: bufferedSeparator})
+}
+
+// flush sizes the columns to fit the header and every buffered row, then
+// prints the header, a separator, and the rows.
+//
+// The label column fits the longest label; all count columns share the width
+// of the widest cell, so weeks stay visually aligned. Each column keeps at
+// least two spaces of gap.
+func (t *weeklyTable) flush(labelTitle string) {
+	labelWidth := utf8.RuneCountInString(labelTitle)
+	cellWidth := 0
+	fit := func(cell string) {
+		if n := utf8.RuneCountInString(cell); n > cellWidth {
+			cellWidth = n
+		}
+	}
+	for _, week := range t.weeks {
+		fit(formatWeekEnd(week))
+	}
+	fit("Current")
+	fit("Total")
+	if t.showChange {
+		fit("WoW %")
+	}
+	for _, row := range t.buffered {
+		if row.kind == bufferedText {
+			if n := utf8.RuneCountInString(row.label); n > labelWidth {
+				labelWidth = n
+			}
+			for _, cell := range row.cells {
+				fit(cell)
+			}
+			continue
+		}
+		if row.kind != bufferedData && row.kind != bufferedTotals {
+			continue
+		}
+		if n := utf8.RuneCountInString(row.label); n > labelWidth {
+			labelWidth = n
+		}
+		total := 0
+		counts := make([]int, len(t.weeks))
+		for i, week := range t.weeks {
+			fit(strconv.Itoa(row.values[week]))
+			total += row.values[week]
+			counts[i] = row.values[week]
+		}
+		fit(strconv.Itoa(row.values[t.currentWeek]))
+		fit(strconv.Itoa(total))
+		if t.showChange {
+			fit(weekOverWeek(counts))
+		}
+	}
+	t.labelColWidth = labelWidth + 2
+	t.weekColWidth = cellWidth + 2
+
+	t.printHeader(labelTitle, t.currentWeek)
+	t.printSeparator(t.currentWeek)
+	for _, row := range t.buffered {
+		switch row.kind {
+		case bufferedData:
+			t.printRow(row.label, row.values, t.currentWeek)
+		case bufferedTotals:
+			t.printTotalsRow(row.label, row.values, t.currentWeek)
+		case bufferedSection:
+			t.printSection(row.label)
+		case bufferedSeparator:
+			t.printSeparator(t.currentWeek)
+		case bufferedText:
+			t.printTextRow(row.label, row.cells)
+		}
+	}
+	t.buffered = nil
+}
+
+// gridFormat reports whether --output asks for CSV or markdown tables rather
+// than fixed-width text.
+func gridFormat() bool {
+	return delimitedFormat() || outputFormat == "markdown"
+}
+
+// delimitedFormat reports whether --output is CSV or TSV. These carry only
+// the data rows, without titles, sections, notes, or sparklines, so they
+// stay machine-readable.
+func delimitedFormat() bool {
+	return outputFormat == "csv" || outputFormat == "tsv"
 }
 
 // printHeader prints the table header with week ending dates.
 func (t *weeklyTable) printHeader(labelTitle string, currentWeek string) {
-	fmt.Printf("%-*s", t.labelColWidth, labelTitle)
+	if t.format != "" {
+		cells := []string{labelTitle}
+		for _, week := range t.weeks {
+			if t.format != "markdown" {
+				cells = append(cells, weekStartToEnd(week))
+			} else {
+				cells = append(cells, formatWeekEnd(week))
+			}
+		}
+		if currentWeek != "" {
+			cells = append(cells, "Current")
+		}
+		cells = append(cells, "Total")
+		if t.showChange {
+			cells = append(cells, "WoW %")
+		}
+		if t.showTrend {
+			cells = append(cells, "Trend")
+		}
+		printRecord(t.format, cells)
+		if t.format == "markdown" {
+			printMarkdownRule(len(cells))
+		}
+		return
+	}
+	header := fmt.Sprintf("%-*s", t.labelColWidth, labelTitle)
 	for _, week := range t.weeks {
-		fmt.Printf("%*s", t.weekColWidth, formatWeekEnd(week))
+		header += fmt.Sprintf("%*s", t.weekColWidth, formatWeekEnd(week))
 	}
 	if currentWeek != "" {
-		fmt.Printf("%*s", t.weekColWidth, "Current")
+		header += fmt.Sprintf("%*s", t.weekColWidth, "Current")
+	}
+	header += fmt.Sprintf("%*s", t.weekColWidth, "Total")
+	if t.showChange {
+		header += fmt.Sprintf("%*s", t.weekColWidth, "WoW %")
+	}
+	if t.showTrend {
+		header += fmt.Sprintf("  %-*s", t.trendWidth(), "Trend")
 	}
-	fmt.Printf("%*s\n", t.weekColWidth, "Total")
+	fmt.Fprintln(stdout, t.style(ansiBold, header))
 }
 
 // printSeparator prints a horizontal separator line.
 func (t *weeklyTable) printSeparator(currentWeek string) {
+	if t.format != "" {
+		return
+	}
 	columns := len(t.weeks) + 1 // weeks + Total
 	if currentWeek != "" {
 		columns++ // add Current column
 	}
+	if t.showChange {
+		columns++ // add WoW % column
+	}
 	totalWidth := t.labelColWidth + t.weekColWidth*columns
-	fmt.Println(strings.Repeat("-", totalWidth))
+	if t.showTrend {
+		totalWidth += 2 + t.trendWidth()
+	}
+	fmt.Fprintln(stdout, strings.Repeat("-", totalWidth))
 }
 
 // printRow prints a data row with label, weekly values, optional current week, and total.
 // weekValues is a map from week (Monday date string) to count.
-// Zero values are displayed as "-".
+// Zero values are displayed as "-" unless --zero says otherwise.
 func (t *weeklyTable) printRow(label string, weekValues map[string]int, currentWeek string) int {
-	fmt.Printf("%-*s", t.labelColWidth, label)
+	if t.format != "" {
+		counts := make([]int, len(t.weeks))
+		for i, week := range t.weeks {
+			counts[i] = weekValues[week]
+		}
+		current := -1
+		if currentWeek != "" {
+			current = weekValues[currentWeek]
+		}
+		return t.printRecordRow(label, counts, current)
+	}
+	t.printLabel(label)
 	total := 0
-	for _, week := range t.weeks {
+	counts := make([]int, len(t.weeks))
+	for i, week := range t.weeks {
 		count := weekValues[week]
-		if count == 0 {
-			fmt.Printf("%*s", t.weekColWidth, "-")
-		} else {
-			fmt.Printf("%*d", t.weekColWidth, count)
-		}
+		t.printCount(count)
 		total += count
+		counts[i] = count
 	}
 	if currentWeek != "" {
-		count := weekValues[currentWeek]
-		if count == 0 {
-			fmt.Printf("%*s", t.weekColWidth, "-")
-		} else {
-			fmt.Printf("%*d", t.weekColWidth, count)
-		}
+		t.printCount(weekValues[currentWeek])
 		// Don't add current week to total
 	}
-	fmt.Printf("%*d\n", t.weekColWidth, total)
+	fmt.Fprintf(stdout, "%*d", t.weekColWidth, total)
+	t.printChange(counts)
+	t.printTrend(counts)
+	fmt.Fprintln(stdout)
 	return total
 }
 
@@ -76,50 +348,391 @@ func (t *weeklyTable) printRow(label string, weekValues map[string]int, currentW
 // If currentCount >= 0, it's displayed in the Current column (not added to total).
 // Use currentCount = -1 to skip the current week column.
 func (t *weeklyTable) printRowWithSlice(label string, counts []int, currentCount int) int {
-	fmt.Printf("%-*s", t.labelColWidth, label)
+	if t.format != "" {
+		return t.printRecordRow(label, counts, currentCount)
+	}
+	t.printLabel(label)
 	total := 0
 	for _, count := range counts {
-		if count == 0 {
-			fmt.Printf("%*s", t.weekColWidth, "-")
-		} else {
-			fmt.Printf("%*d", t.weekColWidth, count)
-		}
+		t.printCount(count)
 		total += count
 	}
 	if currentCount >= 0 {
-		if currentCount == 0 {
-			fmt.Printf("%*s", t.weekColWidth, "-")
-		} else {
-			fmt.Printf("%*d", t.weekColWidth, currentCount)
-		}
+		t.printCount(currentCount)
 		// Don't add current week to total
 	}
-	fmt.Printf("%*d\n", t.weekColWidth, total)
+	fmt.Fprintf(stdout, "%*d", t.weekColWidth, total)
+	t.printChange(counts)
+	t.printTrend(counts)
+	fmt.Fprintln(stdout)
 	return total
 }
 
 // printTotalsRow prints a totals row with week totals, optional current week total, and grand total.
 // weekTotals is a map from week to total count for that week.
 func (t *weeklyTable) printTotalsRow(label string, weekTotals map[string]int, currentWeek string) {
-	fmt.Printf("%-*s", t.labelColWidth, label)
+	if t.format == "markdown" {
+		label = "**" + strings.TrimSpace(label) + "**"
+	}
+	if t.format != "" {
+		t.printRow(label, weekTotals, currentWeek)
+		return
+	}
+	// The whole row is bold, so cells are not colored individually
+	row := t.padLabel(label)
 	grandTotal := 0
-	for _, week := range t.weeks {
+	counts := make([]int, len(t.weeks))
+	for i, week := range t.weeks {
 		total := weekTotals[week]
-		if total == 0 {
-			fmt.Printf("%*s", t.weekColWidth, "-")
-		} else {
-			fmt.Printf("%*d", t.weekColWidth, total)
-		}
+		row += t.countCell(total)
 		grandTotal += total
+		counts[i] = total
 	}
 	if currentWeek != "" {
-		total := weekTotals[currentWeek]
-		if total == 0 {
-			fmt.Printf("%*s", t.weekColWidth, "-")
-		} else {
-			fmt.Printf("%*d", t.weekColWidth, total)
-		}
+		row += t.countCell(weekTotals[currentWeek])
 		// Don't add current week to grand total
 	}
-	fmt.Printf("%*d\n", t.weekColWidth, grandTotal)
+	row += fmt.Sprintf("%*d", t.weekColWidth, grandTotal)
+	if t.showChange {
+		row += fmt.Sprintf("%*s", t.weekColWidth, weekOverWeek(counts))
+	}
+	if t.showTrend {
+		row += "  " + sparkline(counts)
+	}
+	fmt.Fprintln(stdout, t.style(ansiBold, row))
+}
+
+// printLabel prints the left-aligned label column.
+func (t *weeklyTable) printLabel(label string) {
+	fmt.Fprint(stdout, t.padLabel(label))
+}
+
+// padLabel pads label to the label column width. Padding is based on the
+// number of runes so labels containing symbols like "Δ" stay aligned.
+func (t *weeklyTable) padLabel(label string) string {
+	if pad := t.labelColWidth - utf8.RuneCountInString(label); pad > 0 {
+		return label + strings.Repeat(" ", pad)
+	}
+	return label
+}
+
+// countCell returns a weekly cell right-aligned in its column. Zero values
+// are displayed as "-" by default; see --zero.
+func (t *weeklyTable) countCell(count int) string {
+	cell := zeroCell()
+	if count != 0 {
+		cell = fmt.Sprintf("%d", count)
+	}
+	return fmt.Sprintf("%*s", t.weekColWidth, cell)
+}
+
+// printCount prints a single weekly cell.
+// When color is enabled and the table has a cellColor function, the value is
+// wrapped in the returned ANSI code; otherwise zero cells shown as "-" are
+// dimmed, while --zero zero keeps its "0" at full strength.
+func (t *weeklyTable) printCount(count int) {
+	code := ""
+	if t.cellColor != nil {
+		code = t.cellColor(count)
+	}
+	if code == "" && count == 0 && zeroCell() == "-" {
+		code = ansiGray
+	}
+	fmt.Fprint(stdout, t.style(code, t.countCell(count)))
+}
+
+// printChange prints the WoW % cell for a row's weekly counts when the
+// table shows that column.
+func (t *weeklyTable) printChange(counts []int) {
+	if t.showChange {
+		fmt.Fprintf(stdout, "%*s", t.weekColWidth, weekOverWeek(counts))
+	}
+}
+
+// printTrend prints the sparkline cell for a row's weekly counts when the
+// table shows that column.
+func (t *weeklyTable) printTrend(counts []int) {
+	if t.showTrend {
+		fmt.Fprint(stdout, "  "+sparkline(counts))
+	}
+}
+
+// trendWidth is the width of the Trend column: one rune per week, but never
+// narrower than its header.
+func (t *weeklyTable) trendWidth() int {
+	if len(t.weeks) < len("Trend") {
+		return len("Trend")
+	}
+	return len(t.weeks)
+}
+
+// sparkline renders counts as a row of block characters scaled to the row's
+// own min and max. A flat row is drawn at the lowest height.
+func sparkline(counts []int) string {
+	if len(counts) == 0 {
+		return ""
+	}
+	lo, hi := counts[0], counts[0]
+	for _, c := range counts {
+		if c < lo {
+			lo = c
+		}
+		if c > hi {
+			hi = c
+		}
+	}
+	var b strings.Builder
+	for _, c := range counts {
+		level := 0
+		if hi > lo {
+			level = (c - lo) * (len(sparkBlocks) - 1) / (hi - lo)
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+	return b.String()
+}
+
+// weekOverWeek formats the percent change of the last completed week versus
+// the week before it, e.g. "+25%". There is no meaningful percentage when the
+// earlier week is zero, so that case reads "n/a".
+func weekOverWeek(counts []int) string {
+	if len(counts) < 2 {
+		return "n/a"
+	}
+	prev, last := counts[len(counts)-2], counts[len(counts)-1]
+	if prev == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%+.0f%%", float64(last-prev)/float64(prev)*100)
+}
+
+// style wraps s in an ANSI code when color output is enabled, and returns s
+// unchanged otherwise.
+func (t *weeklyTable) style(code, s string) string {
+	if !colorEnabled() {
+		return s
+	}
+	return colorize(code, s)
+}
+
+// printTextRow prints a row of preformatted cells, such as percentages.
+// cells holds one value per week, followed by the current week (if the table
+// shows one) and the total, each right-aligned in its column.
+func (t *weeklyTable) printTextRow(label string, cells []string) {
+	if t.showChange {
+		// Rates and durations have no week-over-week figure
+		cells = append(cells, "")
+	}
+	if t.showTrend && t.format != "" {
+		cells = append(cells, "")
+	}
+	if t.format != "" {
+		printRecord(t.format, append([]string{label}, cells...))
+		return
+	}
+	t.printLabel(label)
+	for _, cell := range cells {
+		code := ""
+		if cell == "-" {
+			code = ansiGray
+		}
+		fmt.Fprint(stdout, t.style(code, fmt.Sprintf("%*s", t.weekColWidth, cell)))
+	}
+	fmt.Fprintln(stdout)
+}
+
+// printSection starts a named group of rows, such as a department.
+func (t *weeklyTable) printSection(name string) {
+	switch t.format {
+	case "csv", "tsv":
+		// Sections have no place in CSV or TSV; rows stand on their own
+	case "markdown":
+		cells := make([]string, len(t.weeks)+3)
+		if t.showChange {
+			cells = append(cells, "")
+		}
+		if t.showTrend {
+			cells = append(cells, "")
+		}
+		cells[0] = "**" + name + "**"
+		printRecord(t.format, cells)
+	default:
+		fmt.Fprintf(stdout, "\n%s\n", name)
+	}
+}
+
+// printRecordRow prints a row of counts as a CSV or markdown record and
+// returns the total of the weekly counts. A negative currentCount omits the
+// Current cell.
+func (t *weeklyTable) printRecordRow(label string, counts []int, currentCount int) int {
+	cells := []string{strings.TrimSpace(label)}
+	total := 0
+	for _, count := range counts {
+		cells = append(cells, t.recordCell(count))
+		total += count
+	}
+	if currentCount >= 0 {
+		cells = append(cells, t.recordCell(currentCount))
+	}
+	cells = append(cells, strconv.Itoa(total))
+	if t.showChange {
+		cells = append(cells, weekOverWeek(counts))
+	}
+	if t.showTrend {
+		cells = append(cells, sparkline(counts))
+	}
+	printRecord(t.format, cells)
+	return total
+}
+
+// recordCell formats a count for CSV (always a number) or markdown (zeros
+// follow --zero, as in text tables).
+func (t *weeklyTable) recordCell(count int) string {
+	if count == 0 && t.format == "markdown" {
+		return zeroCell()
+	}
+	return strconv.Itoa(count)
+}
+
+// tsvReplacer turns the characters TSV cannot escape into spaces.
+var tsvReplacer = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+// printRecord writes one row of cells as CSV, TSV, or a GitHub-flavored
+// markdown table row.
+func printRecord(format string, cells []string) {
+	switch format {
+	case "csv":
+		w := csv.NewWriter(stdout)
+		w.Write(cells)
+		w.Flush()
+		return
+	case "tsv":
+		// TSV has no quoting, so tabs and line breaks in a cell become spaces
+		sanitized := make([]string, len(cells))
+		for i, cell := range cells {
+			sanitized[i] = tsvReplacer.Replace(cell)
+		}
+		fmt.Fprintln(stdout, strings.Join(sanitized, "\t"))
+		return
+	}
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = strings.ReplaceAll(cell, "|", `\|`)
+	}
+	fmt.Fprintln(stdout, "| "+strings.Join(escaped, " | ")+" |")
+}
+
+// printMarkdownRule prints the markdown header separator for n columns, with
+// the first (label) column left-aligned and the rest right-aligned.
+func printMarkdownRule(n int) {
+	rule := []string{":---"}
+	for i := 1; i < n; i++ {
+		rule = append(rule, "---:")
+	}
+	fmt.Fprintln(stdout, "| "+strings.Join(rule, " | ")+" |")
+}
+
+// printTableTitle prints the title line above a table, followed by a blank
+// line. Markdown titles are bold; CSV has no title so it stays machine-readable.
+func printTableTitle(format string, a ...interface{}) {
+	title := fmt.Sprintf(format, a...)
+	switch outputFormat {
+	case "csv", "tsv":
+	case "markdown":
+		fmt.Fprintf(stdout, "**%s**\n\n", title)
+	default:
+		fmt.Fprintf(stdout, "%s\n\n", title)
+	}
+}
+
+// printTableNote prints a line of commentary after a table, such as a
+// summary figure. Nothing is printed for CSV.
+func printTableNote(format string, a ...interface{}) {
+	if !delimitedFormat() {
+		fmt.Fprintf(stdout, format, a...)
+	}
+}
+
+// printGrid prints a simple table with one header row for commands whose
+// fixed-width layout is not a weeklyTable, when --output is csv or markdown.
+func printGrid(headers []string, rows [][]string) {
+	printRecord(outputFormat, headers)
+	if outputFormat == "markdown" {
+		printMarkdownRule(len(headers))
+	}
+	for _, row := range rows {
+		printRecord(outputFormat, row)
+	}
+}
+
+// tableRow is a named series of counts keyed by week (Monday date string).
+type tableRow struct {
+	label  string
+	values map[string]int
+}
+
+// tableBuilder collects rows from different sources that share the same week
+// columns and renders them together as a single aligned weeklyTable.
+type tableBuilder struct {
+	table       *weeklyTable
+	currentWeek string
+	rows        []tableRow
+}
+
+// newTableBuilder creates a builder that renders into table.
+// If currentWeek is non-empty, a Current column is included.
+func newTableBuilder(table *weeklyTable, currentWeek string) *tableBuilder {
+	return &tableBuilder{table: table, currentWeek: currentWeek}
+}
+
+// addRow appends a row. values is a map from week (Monday date string) to count.
+func (b *tableBuilder) addRow(label string, values map[string]int) {
+	b.rows = append(b.rows, tableRow{label: label, values: values})
+}
+
+// render prints the header, separator, and all collected rows.
+func (b *tableBuilder) render(labelTitle string) {
+	b.table.printHeader(labelTitle, b.currentWeek)
+	b.table.printSeparator(b.currentWeek)
+	for _, row := range b.rows {
+		b.table.printRow(row.label, row.values, b.currentWeek)
+	}
+}
+
+// formatPercent renders a rate as a whole percentage for printTextRow,
+// or "-" when there is no rate to show.
+func formatPercent(rate float64, ok bool) string {
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", rate)
+}
+
+// humanizeDuration renders a duration compactly using its two largest units,
+// e.g. "3d 4h", "5h 12m", "45m", or "30s".
+func humanizeDuration(d time.Duration) string {
+	if d < 0 {
+		return "-" + humanizeDuration(-d)
+	}
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case minutes > 0:
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%ds", int(d/time.Second))
+}
+
+// formatPerUser renders a per-active-user rate for printTextRow,
+// or "-" when there is no rate to show.
+func formatPerUser(rate float64, ok bool) string {
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprintf("%.3f", rate)
 }
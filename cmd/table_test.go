@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPrintCountDimsOnlyDashes(t *testing.T) {
+	prevOut, prevMode, prevZero := stdout, colorMode, zeroStyle
+	t.Cleanup(func() {
+		stdout, colorMode, zeroStyle = prevOut, prevMode, prevZero
+	})
+	colorMode = "always"
+
+	tests := []struct {
+		zero  string
+		count int
+		dim   bool
+	}{
+		{"dash", 0, true},
+		{"zero", 0, false},
+		{"blank", 0, false},
+		{"dash", 3, false},
+	}
+	for _, tt := range tests {
+		zeroStyle = tt.zero
+		var buf bytes.Buffer
+		stdout = &buf
+		newWeeklyTable(10, 6, []string{"2026-01-05"}).printCount(tt.count)
+		if got := strings.Contains(buf.String(), ansiGray); got != tt.dim {
+			t.Errorf("--zero %s, count %d: dimmed = %v, want %v (%q)", tt.zero, tt.count, got, tt.dim, buf.String())
+		}
+	}
+}
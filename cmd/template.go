@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Template output (--output template --template-file FILE) renders a report
+// through a user-supplied Go text/template. Every command passes the same
+// templateData shape:
+//
+//	.Meta.Command      command name, e.g. "incidents"
+//	.Meta.Target       org, repo, or other target of the report (may be empty)
+//	.Meta.GeneratedAt  time the report was generated (UTC)
+//	.Weeks             completed weeks, oldest first (.Start, .End, .Label)
+//	.CurrentWeek       the in-progress week (.Start, .End, .Label)
+//	.Rows              one entry per table row (.Label, .Group, .Values, .Current, .Total)
+//	.Totals            column sums across all rows (.Values, .Current, .Total)
+//	.Summary           command-specific scalar values keyed by name
+//
+// Rows per command:
+//
+//	ashby applicants-by-week              one row per job; .Group is the department
+//	ashby applicants-by-week --by-source  one row per source; .Group is the source type
+//	ashby offer-acceptance                "Extended" and "Accepted" rows
+//	ashby rejection-reasons               one row per archive reason
+//	incidents                             one row per label
+//	datum active-users                    a single "Active Users" row; .Summary.total_unique_users
+//	github approvals                      one row per reviewer
+//	github ci                             "Success" and "Failure" rows
+//	github issues-opened-vs-closed        "Opened" and "Closed" rows
+//	github prs                            one row per --state ("Opened", "Closed", "Merged")
+//	github stars                          one row per repository; only .Total (stars) is set
+//	report                                one row per requested source
+//
+// In addition to the standard template functions, add, sub, percent, and join
+// are available.
+
+// outputTemplate holds the parsed --template-file when --output is "template".
+var outputTemplate *template.Template
+
+// templateRendered records that a command rendered --output template.
+var templateRendered bool
+
+var templateFuncs = template.FuncMap{
+	"add": func(a, b int) int { return a + b },
+	"sub": func(a, b int) int { return a - b },
+	"percent": func(part, whole int) string {
+		if whole == 0 {
+			return "n/a"
+		}
+		return fmt.Sprintf("%.1f%%", float64(part)/float64(whole)*100)
+	},
+	"join": strings.Join,
+}
+
+// templateWeek describes one week column.
+type templateWeek struct {
+	Start string // Monday, "2006-01-02"
+	End   string // Sunday, "2006-01-02"
+	Label string // table header label, e.g. "Jan 02"
+}
+
+// templateRow is one row of a report.
+type templateRow struct {
+	Label   string
+	Group   string
+	Values  []int // one per entry in Weeks
+	Current int
+	Total   int // sum of Values (excludes Current)
+}
+
+// templateMeta describes the report being rendered.
+type templateMeta struct {
+	Command     string
+	Target      string
+	GeneratedAt time.Time
+}
+
+// templateData is the value passed to --template-file templates.
+type templateData struct {
+	Meta        templateMeta
+	Weeks       []templateWeek
+	CurrentWeek templateWeek
+	Rows        []templateRow
+	Totals      templateRow
+	Summary     map[string]int
+}
+
+// loadOutputTemplate parses the template file so errors surface before any fetching.
+func loadOutputTemplate(path string) error {
+	if path == "" {
+		return fmt.Errorf("--output template requires --template-file")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read template: %w", err)
+	}
+	tmpl, err := template.New(path).Funcs(templateFuncs).Parse(string(data))
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+	outputTemplate = tmpl
+	return nil
+}
+
+// newTemplateData creates template data for the given weeks and current week.
+func newTemplateData(command, target string, weeks []string, currentWeek string) *templateData {
+	d := &templateData{
+		Meta:    templateMeta{Command: command, Target: target, GeneratedAt: timeNow().UTC()},
+		Totals:  templateRow{Label: "Total", Values: make([]int, len(weeks))},
+		Summary: make(map[string]int),
+	}
+	for _, week := range weeks {
+		d.Weeks = append(d.Weeks, newTemplateWeek(week))
+	}
+	if currentWeek != "" {
+		d.CurrentWeek = newTemplateWeek(currentWeek)
+	}
+	return d
+}
+
+func newTemplateWeek(monday string) templateWeek {
+	return templateWeek{Start: monday, End: weekEndDate(monday), Label: formatWeekEnd(monday)}
+}
+
+// addRow appends a row built from a map of week (Monday date string) to count
+// and adds it to the totals.
+func (d *templateData) addRow(label, group string, weekValues map[string]int) {
+	row := templateRow{Label: label, Group: group, Values: make([]int, len(d.Weeks))}
+	for i, week := range d.Weeks {
+		row.Values[i] = weekValues[week.Start]
+		row.Total += row.Values[i]
+		d.Totals.Values[i] += row.Values[i]
+	}
+	if d.CurrentWeek.Start != "" {
+		row.Current = weekValues[d.CurrentWeek.Start]
+		d.Totals.Current += row.Current
+	}
+	d.Totals.Total += row.Total
+	d.Rows = append(d.Rows, row)
+}
+
+// addTotalRow appends a row that has a single total and no weekly values.
+func (d *templateData) addTotalRow(label, group string, total int) {
+	d.Rows = append(d.Rows, templateRow{Label: label, Group: group, Total: total})
+	d.Totals.Total += total
+}
+
+// wantTemplateData reports whether a command should build templateData: for
+// --output template, or for --prometheus-file alongside any other format.
+func wantTemplateData() bool {
+	return outputFormat == "template" || prometheusFile != ""
+}
+
+// output writes d to --prometheus-file, when set, and renders it when
+// --output is template.
+func (d *templateData) output() error {
+	if err := d.writePrometheus(); err != nil {
+		return err
+	}
+	if outputFormat == "template" {
+		return d.render()
+	}
+	return nil
+}
+
+// render executes the --template-file template against d and writes to stdout.
+func (d *templateData) render() error {
+	if err := outputTemplate.Execute(stdout, d); err != nil {
+		return fmt.Errorf("failed to render template: %w", err)
+	}
+	templateRendered = true
+	return nil
+}
+
+// checkTemplateRendered fails a command that succeeded without rendering
+// --output template, since it never builds templateData.
+func checkTemplateRendered(cmd *cobra.Command) error {
+	if outputFormat != "template" || templateRendered {
+		return nil
+	}
+	return fmt.Errorf("--output template is not supported by %s", cmd.CommandPath())
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// refreshCache holds the value of the persistent --refresh flag, which ignores
+// cached results and refetches every week.
+var refreshCache bool
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&refreshCache, "refresh", false, "Ignore cached weekly results and refetch everything")
+}
+
+// weekCache stores per-week results for one (source, target) pair on disk.
+//
+// Once a week has completed its numbers no longer change, so commands can
+// serve completed weeks from the cache and only refetch the weeks that are
+// missing, which is usually just the in-progress week. The in-progress week
+// is never cached, so a newly completed week is fetched automatically the
+// first time it is reported on.
+type weekCache struct {
+	path    string
+	entries map[string]json.RawMessage
+}
+
+// newWeekCache opens the cache for source (e.g. "incidents") and target
+// (e.g. "org/repo"). Weeks are keyed by their start date, so each report time
+// zone gets its own file: the same date begins at a different instant in
+// another zone. An unreadable or missing cache file starts empty.
+func newWeekCache(source, target string) *weekCache {
+	c := &weekCache{entries: make(map[string]json.RawMessage)}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return c
+	}
+	name := source + "-" + strings.NewReplacer("/", "_", ":", "_", " ", "_").Replace(target+"@"+weekLocation.String()) + ".json"
+	c.path = filepath.Join(dir, "scorecard", "weeks", name)
+
+	if data, err := os.ReadFile(c.path); err == nil {
+		json.Unmarshal(data, &c.entries)
+	}
+	return c
+}
+
+// load decodes the cached result for week into v and reports whether it was found.
+// Nothing is served from the cache with --refresh, --raw, or --dry-run.
+func (c *weekCache) load(week string, v interface{}) bool {
+	if refreshCache || rawOutput != nil || dryRun {
+		return false
+	}
+	data, ok := c.entries[week]
+	if !ok {
+		return false
+	}
+	return json.Unmarshal(data, v) == nil
+}
+
+// store records the result for week if the week has completed.
+func (c *weekCache) store(week string, v interface{}) {
+	if week >= getCurrentWeekStart() {
+		return
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	c.entries[week] = data
+}
+
+// save writes the cache to disk. Failures are not fatal since the cache is
+// only an optimization. Nothing is written with --dry-run.
+func (c *weekCache) save() {
+	if c.path == "" || dryRun {
+		return
+	}
+	data, err := json.Marshal(c.entries)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return
+	}
+	os.WriteFile(c.path, data, 0o644)
+}
@@ -1,49 +1,142 @@
 package cmd
 
-import "time"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
 
-// Week boundaries are Monday 00:00:00 UTC to Sunday 23:59:59 UTC.
+// Week boundaries are Monday 00:00:00 UTC to Sunday 23:59:59 UTC by default;
+// --week-start sunday shifts them to Sunday through Saturday, and --timezone
+// measures them in another zone.
 // Reports show only completed weeks - if run mid-week, the most recent
-// week shown is the one that ended on the previous Sunday.
+// week shown is the one that ended on the previous last day of the week.
 
-// getWeekStart returns the Monday (start) of the week containing time t.
-// The returned string is in "2006-01-02" format.
-func getWeekStart(t time.Time) string {
-	// Convert to UTC for consistent week boundaries
-	t = t.UTC()
+// weekStartDay holds the value of the persistent --week-start flag.
+var weekStartDay string
+
+// firstWeekday is the day weeks start on, as set by --week-start.
+var firstWeekday = time.Monday
+
+// timezoneName holds the value of the persistent --timezone flag.
+var timezoneName string
+
+// isoWeekLabels holds the value of the persistent --iso-weeks flag, which
+// labels weeks by ISO week number (e.g. "2025-W42") instead of end date.
+var isoWeekLabels bool
+
+// dateFormat holds the value of the persistent --date-format flag: a preset
+// name from dateFormatPresets or a Go reference layout.
+var dateFormat string
+
+// weekLabelLayout is the layout week labels are formatted with, resolved from
+// --date-format.
+var weekLabelLayout = "Jan 02"
+
+// dateFormatPresets are the named --date-format values.
+var dateFormatPresets = map[string]string{
+	"short": "Jan 02",
+	"iso":   "2006-01-02",
+	"us":    "01/02",
+	"eu":    "02/01",
+}
+
+// weekLocation is the time zone week boundaries are measured in, as set by
+// --timezone or SCORECARD_TZ.
+var weekLocation = time.UTC
+
+// timeNow is the clock that week calculations and report timestamps read,
+// so tests can pin "now". Elapsed-time logging still uses time.Now.
+var timeNow = time.Now
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&weekStartDay, "week-start", "monday", "First day of the week: monday or sunday")
+	rootCmd.PersistentFlags().BoolVar(&isoWeekLabels, "iso-weeks", false, "Label weeks by ISO week number (e.g. 2025-W42) instead of end date")
+	rootCmd.PersistentFlags().StringVar(&dateFormat, "date-format", "short", `Week label format: short (Jan 02), iso (2006-01-02), us (01/02), eu (02/01), or a Go reference layout`)
+	rootCmd.PersistentFlags().StringVar(&timezoneName, "timezone", "", "Time zone for week boundaries, e.g. America/Los_Angeles (default: $SCORECARD_TZ, config timezone, or UTC)")
+}
 
-	// Get Monday of the week (weekday 0 = Sunday, 1 = Monday, ..., 6 = Saturday)
-	weekday := int(t.Weekday())
-	if weekday == 0 {
-		weekday = 7 // Treat Sunday as day 7
+// resolveTimezone sets weekLocation from --timezone, falling back to the
+// SCORECARD_TZ environment variable, the config file's timezone, and then UTC.
+func resolveTimezone() error {
+	if timezoneName == "" {
+		timezoneName = envOrConfig("SCORECARD_TZ")
+	}
+	if timezoneName == "" {
+		weekLocation = time.UTC
+		return nil
 	}
-	monday := t.AddDate(0, 0, -(weekday - 1))
-	return monday.Format("2006-01-02")
+	loc, err := time.LoadLocation(timezoneName)
+	if err != nil {
+		return fmt.Errorf("invalid time zone %q: %w", timezoneName, err)
+	}
+	weekLocation = loc
+	return nil
 }
 
-// getLastCompletedWeekStart returns the Monday of the most recently completed week.
-// A week is considered complete when Sunday 23:59:59 UTC has passed.
-func getLastCompletedWeekStart() string {
-	now := time.Now().UTC()
+// parseWeekStart returns the instant a week begins: midnight at the start of
+// the given "2006-01-02" date in the report time zone.
+func parseWeekStart(week string) (time.Time, error) {
+	return time.ParseInLocation("2006-01-02", week, weekLocation)
+}
+
+// validateWeekStart checks --week-start and applies it to firstWeekday.
+func validateWeekStart() error {
+	switch weekStartDay {
+	case "monday":
+		firstWeekday = time.Monday
+	case "sunday":
+		firstWeekday = time.Sunday
+	default:
+		return fmt.Errorf("invalid --week-start value %q (must be monday or sunday)", weekStartDay)
+	}
+	return nil
+}
 
-	// Find the most recent Sunday that has fully passed
-	weekday := int(now.Weekday())
-	var lastSunday time.Time
-	if weekday == 0 {
-		// Today is Sunday - the last completed week ended last Sunday
-		lastSunday = now.AddDate(0, 0, -7)
-	} else {
-		// Go back to the most recent Sunday
-		lastSunday = now.AddDate(0, 0, -weekday)
+// validateDateFormat resolves --date-format into weekLabelLayout. A custom
+// layout must tell different days apart, which also rejects text with no
+// date fields in it at all.
+func validateDateFormat() error {
+	if isoWeekLabels && dateFormat != "short" {
+		return fmt.Errorf("--date-format cannot be combined with --iso-weeks")
 	}
+	layout, ok := dateFormatPresets[dateFormat]
+	if !ok {
+		layout = dateFormat
+		ref := time.Date(2025, time.October, 19, 0, 0, 0, 0, time.UTC)
+		if strings.TrimSpace(layout) == "" || ref.Format(layout) == ref.AddDate(0, 0, 1).Format(layout) {
+			return fmt.Errorf("invalid --date-format %q (must be short, iso, us, eu, or a Go reference layout such as \"Jan 02\" that includes the day)", dateFormat)
+		}
+	}
+	weekLabelLayout = layout
+	return nil
+}
+
+// getWeekStart returns the first day (Monday, or Sunday with --week-start
+// sunday) of the week containing time t, in the report time zone.
+// The returned string is in "2006-01-02" format.
+func getWeekStart(t time.Time) string {
+	// Convert to the report time zone for consistent week boundaries
+	t = t.In(weekLocation)
+
+	// Days since the start of the week (weekday 0 = Sunday, ..., 6 = Saturday)
+	offset := (int(t.Weekday()) - int(firstWeekday) + 7) % 7
+	return t.AddDate(0, 0, -offset).Format("2006-01-02")
+}
 
-	// The week that ended on lastSunday started on the Monday before
-	monday := lastSunday.AddDate(0, 0, -6)
-	return monday.Format("2006-01-02")
+// getLastCompletedWeekStart returns the first day of the most recently
+// completed week. A week is considered complete when its last day (Sunday,
+// or Saturday for Sunday-start weeks) has passed 23:59:59 in the report time
+// zone, so it is simply the week before the current one: on a Sunday the
+// Monday-start week containing it is still in progress.
+func getLastCompletedWeekStart() string {
+	current, _ := time.Parse("2006-01-02", getCurrentWeekStart())
+	return current.AddDate(0, 0, -7).Format("2006-01-02")
 }
 
 // getLastNWeeks returns the last N completed weeks, oldest first.
-// Each entry is the Monday (start date) of that week in "2006-01-02" format.
+// Each entry is the start date of that week in "2006-01-02" format.
 func getLastNWeeks(n int) []string {
 	lastWeekStart := getLastCompletedWeekStart()
 	t, _ := time.Parse("2006-01-02", lastWeekStart)
@@ -56,32 +149,173 @@ func getLastNWeeks(n int) []string {
 	return weeks
 }
 
-// getLast4Weeks returns the last 4 completed weeks, oldest first.
-func getLast4Weeks() []string {
-	return getLastNWeeks(4)
+// getLastNWeeksIncludingCurrent returns the last N completed weeks followed
+// by the current (in-progress) week, oldest first. The final bucket is
+// partial: it only covers the week so far, so callers should label it as
+// such (see isPartialWeek) and not compare it directly to complete weeks.
+func getLastNWeeksIncludingCurrent(n int) []string {
+	return append(getLastNWeeks(n), getCurrentWeekStart())
 }
 
-// getCurrentWeekStart returns the Monday of the current (in-progress) week.
+// isPartialWeek reports whether week is the current, in-progress week.
+func isPartialWeek(week string) bool {
+	return week == getCurrentWeekStart()
+}
+
+// getCurrentWeekStart returns the first day of the current (in-progress) week.
 func getCurrentWeekStart() string {
-	return getWeekStart(time.Now())
+	return getWeekStart(timeNow())
 }
 
-// getLast26Weeks returns the last 26 completed weeks (6 months), oldest first.
-func getLast26Weeks() []string {
-	return getLastNWeeks(26)
+// weekStartToEnd converts a week's first day to the label used for the week in
+// JSON and CSV output: its last day, six days later (Monday to Sunday, or
+// Sunday to Saturday), in "2006-01-02" format, or its ISO week with --iso-weeks.
+func weekStartToEnd(start string) string {
+	if isoWeekLabels {
+		return formatISOWeek(start)
+	}
+	return weekEndDate(start)
 }
 
-// weekStartToEnd converts a Monday date string to the corresponding Sunday date string.
-// Input and output are in "2006-01-02" format.
-func weekStartToEnd(monday string) string {
-	t, _ := time.Parse("2006-01-02", monday)
-	sunday := t.AddDate(0, 0, 6)
-	return sunday.Format("2006-01-02")
+// weekEndDate converts a week's first day to its last day, whatever
+// --iso-weeks says. Input and output are in "2006-01-02" format.
+func weekEndDate(start string) string {
+	t, _ := time.Parse("2006-01-02", start)
+	return t.AddDate(0, 0, 6).Format("2006-01-02")
 }
 
-// formatWeekEnd formats a Monday date string as the corresponding Sunday in "Jan 02" format.
-func formatWeekEnd(monday string) string {
-	t, _ := time.Parse("2006-01-02", monday)
-	sunday := t.AddDate(0, 0, 6)
-	return sunday.Format("Jan 02")
+// formatWeekEnd formats a week's first day as its last day for table headers,
+// in "Jan 02" format unless --date-format says otherwise, or as its ISO week
+// with --iso-weeks.
+func formatWeekEnd(start string) string {
+	if isoWeekLabels {
+		return formatISOWeek(start)
+	}
+	t, _ := time.Parse("2006-01-02", start)
+	return t.AddDate(0, 0, 6).Format(weekLabelLayout)
+}
+
+// formatISOWeek formats a week's first day as its ISO week, e.g. "2025-W42".
+// The week is identified by its fourth day so that Sunday-start weeks take
+// the number of the ISO week they mostly overlap.
+func formatISOWeek(start string) string {
+	t, _ := time.Parse("2006-01-02", start)
+	year, week := t.AddDate(0, 0, 3).ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// parseDateRef parses a date reference as used by --since/--until flags.
+// Accepted forms mirror the relative syntax datumctl uses for --start-time:
+//
+//	2006-01-02   a calendar date (midnight in the report time zone)
+//	now          the current time
+//	now-Nd       N days ago
+//	now-Nw       N weeks ago
+//	this-week    the first day of the current (in-progress) week
+//	last-week    the first day of the most recently completed week
+func parseDateRef(ref string) (time.Time, error) {
+	now := timeNow().In(weekLocation)
+	switch ref {
+	case "now":
+		return now, nil
+	case "this-week":
+		return parseWeekStart(getCurrentWeekStart())
+	case "last-week":
+		return parseWeekStart(getLastCompletedWeekStart())
+	}
+
+	if rest, ok := strings.CutPrefix(ref, "now-"); ok && len(rest) > 1 {
+		n, err := strconv.Atoi(rest[:len(rest)-1])
+		if err == nil && n >= 0 {
+			switch rest[len(rest)-1] {
+			case 'd':
+				return now.AddDate(0, 0, -n), nil
+			case 'w':
+				return now.AddDate(0, 0, -7*n), nil
+			}
+		}
+	}
+
+	if t, err := parseWeekStart(ref); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("unknown date reference %q (use YYYY-MM-DD, now, now-Nd, now-Nw, this-week, or last-week)", ref)
+}
+
+// getWeeksBetween returns the completed weeks overlapping since..until, oldest first.
+// Each entry is the start date of that week in "2006-01-02" format.
+// The in-progress week is never included.
+func getWeeksBetween(since, until time.Time) []string {
+	last, _ := time.Parse("2006-01-02", getLastCompletedWeekStart())
+	end, _ := time.Parse("2006-01-02", getWeekStart(until))
+	if end.After(last) {
+		end = last
+	}
+
+	var weeks []string
+	t, _ := time.Parse("2006-01-02", getWeekStart(since))
+	for !t.After(end) {
+		weeks = append(weeks, t.Format("2006-01-02"))
+		t = t.AddDate(0, 0, 7)
+	}
+	return weeks
+}
+
+// resolveWeeks returns the weeks to report on. With neither since nor until
+// set it returns the last defaultN completed weeks; otherwise the references
+// are parsed with parseDateRef (an empty until means "now", an empty since
+// means defaultN weeks before until).
+func resolveWeeks(since, until string, defaultN int) ([]string, error) {
+	if since == "" && until == "" {
+		return getLastNWeeks(defaultN), nil
+	}
+
+	end := timeNow().In(weekLocation)
+	if until != "" {
+		t, err := parseDateRef(until)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --until: %w", err)
+		}
+		end = t
+	}
+
+	start := end.AddDate(0, 0, -7*(defaultN-1))
+	if since != "" {
+		t, err := parseDateRef(since)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --since: %w", err)
+		}
+		start = t
+	}
+
+	weeks := getWeeksBetween(start, end)
+	if len(weeks) == 0 {
+		return nil, fmt.Errorf("no completed weeks between %s and %s", start.Format("2006-01-02"), end.Format("2006-01-02"))
+	}
+	return weeks, nil
+}
+
+// describeWeeks returns a short title suffix for a week list, e.g. "Last 4 Weeks"
+// or "Weeks Ending Sep 07 - Sep 28" when the list does not end at the last completed week.
+func describeWeeks(weeks []string) string {
+	if len(weeks) == 0 {
+		return "No Weeks"
+	}
+	if weeks[len(weeks)-1] == getLastCompletedWeekStart() {
+		if len(weeks) == 1 {
+			return "Last Week"
+		}
+		return fmt.Sprintf("Last %d Weeks", len(weeks))
+	}
+	return fmt.Sprintf("Weeks Ending %s - %s", formatWeekEnd(weeks[0]), formatWeekEnd(weeks[len(weeks)-1]))
+}
+
+// isWeekend reports whether t falls on a Saturday or Sunday in the report
+// time zone.
+func isWeekend(t time.Time) bool {
+	switch t.In(weekLocation).Weekday() {
+	case time.Saturday, time.Sunday:
+		return true
+	}
+	return false
 }
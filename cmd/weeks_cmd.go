@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var weeksCmd = &cobra.Command{
+	Use:   "weeks",
+	Short: "List the weeks a report will cover",
+	Long: `Print the start and end date of each completed week in the report window.
+
+Weeks run Monday 00:00 UTC to Sunday 23:59 UTC, or Sunday to Saturday with
+--week-start sunday; --timezone (or SCORECARD_TZ) measures them in another
+zone. By default the window is the last 4 completed weeks; use --weeks to
+change its length, or --since and --until to choose a range the same way the
+report commands do. The current (in-progress) week is listed separately.
+
+No API calls are made, so this is a cheap way to check week boundaries before
+running an expensive query.`,
+	Args: cobra.NoArgs,
+	RunE: runWeeks,
+}
+
+func init() {
+	rootCmd.AddCommand(weeksCmd)
+	weeksCmd.Flags().Bool("json", false, "Output in JSON format")
+	weeksCmd.Flags().Int("weeks", 4, "Number of completed weeks in the window")
+	weeksCmd.Flags().String("since", "", "First week to show (YYYY-MM-DD, now-4w, last-week, ...)")
+	weeksCmd.Flags().String("until", "", "Last week to show (YYYY-MM-DD, now, last-week, ...)")
+}
+
+func runWeeks(cmd *cobra.Command, args []string) error {
+	outputJSON := jsonOutput()
+	n := weeksFlag(cmd)
+	since, _ := cmd.Flags().GetString("since")
+	until, _ := cmd.Flags().GetString("until")
+
+	if n < 1 {
+		return fmt.Errorf("--weeks must be at least 1")
+	}
+
+	weeks, err := resolveWeeks(since, until, n)
+	if err != nil {
+		return err
+	}
+	currentWeek := getCurrentWeekStart()
+
+	if outputJSON {
+		return printWeeksJSON(weeks, currentWeek)
+	}
+
+	if gridFormat() {
+		var rows [][]string
+		for _, week := range weeks {
+			rows = append(rows, []string{week, weekEndDate(week), formatWeekEnd(week)})
+		}
+		rows = append(rows, []string{currentWeek, weekEndDate(currentWeek), "Current"})
+		printGrid([]string{"Start", "End", "Label"}, rows)
+		return nil
+	}
+
+	fmt.Fprintln(stdout, describeWeeks(weeks))
+	fmt.Fprintln(stdout)
+	fmt.Fprintf(stdout, "%-12s %-12s %s\n", "Start", "End", "Label")
+	for _, week := range weeks {
+		fmt.Fprintf(stdout, "%-12s %-12s %s\n", week, weekEndDate(week), formatWeekEnd(week))
+	}
+	fmt.Fprintf(stdout, "%-12s %-12s %s\n", currentWeek, weekEndDate(currentWeek), "Current")
+	return nil
+}
+
+func printWeeksJSON(weeks []string, currentWeek string) error {
+	output := WeeksJSON{
+		CurrentWeek: WeeksWeekJSON{Start: currentWeek, End: weekEndDate(currentWeek), Label: "Current"},
+	}
+	for _, week := range weeks {
+		output.Weeks = append(output.Weeks, WeeksWeekJSON{Start: week, End: weekEndDate(week), Label: formatWeekEnd(week)})
+	}
+	return printJSON(output)
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+// setClock pins timeNow, firstWeekday, and weekLocation for one test.
+func setClock(t *testing.T, now time.Time, start time.Weekday, loc *time.Location) {
+	t.Helper()
+	prevNow, prevStart, prevLoc := timeNow, firstWeekday, weekLocation
+	t.Cleanup(func() {
+		timeNow, firstWeekday, weekLocation = prevNow, prevStart, prevLoc
+	})
+	timeNow = func() time.Time { return now }
+	firstWeekday = start
+	weekLocation = loc
+}
+
+func TestGetLastCompletedWeekStart(t *testing.T) {
+	tests := []struct {
+		name  string
+		now   time.Time
+		start time.Weekday
+		want  string
+	}{
+		// Every day of the Monday-start week of Oct 13-19, 2025.
+		{"monday midnight", time.Date(2025, 10, 13, 0, 0, 0, 0, time.UTC), time.Monday, "2025-10-06"},
+		{"tuesday", time.Date(2025, 10, 14, 12, 0, 0, 0, time.UTC), time.Monday, "2025-10-06"},
+		{"wednesday", time.Date(2025, 10, 15, 12, 0, 0, 0, time.UTC), time.Monday, "2025-10-06"},
+		{"thursday", time.Date(2025, 10, 16, 12, 0, 0, 0, time.UTC), time.Monday, "2025-10-06"},
+		{"friday", time.Date(2025, 10, 17, 12, 0, 0, 0, time.UTC), time.Monday, "2025-10-06"},
+		{"saturday", time.Date(2025, 10, 18, 12, 0, 0, 0, time.UTC), time.Monday, "2025-10-06"},
+		{"sunday morning", time.Date(2025, 10, 19, 0, 0, 0, 0, time.UTC), time.Monday, "2025-10-06"},
+		{"sunday last second", time.Date(2025, 10, 19, 23, 59, 59, 0, time.UTC), time.Monday, "2025-10-06"},
+		{"next monday", time.Date(2025, 10, 20, 0, 0, 0, 0, time.UTC), time.Monday, "2025-10-13"},
+
+		// Sunday-start weeks complete at the end of Saturday.
+		{"sunday start, saturday", time.Date(2025, 10, 18, 23, 59, 59, 0, time.UTC), time.Sunday, "2025-10-05"},
+		{"sunday start, sunday", time.Date(2025, 10, 19, 0, 0, 0, 0, time.UTC), time.Sunday, "2025-10-12"},
+		{"sunday start, monday", time.Date(2025, 10, 20, 12, 0, 0, 0, time.UTC), time.Sunday, "2025-10-12"},
+
+		// Year boundaries.
+		{"new year's day 2026", time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC), time.Monday, "2025-12-22"},
+		{"sunday jan 4 2026", time.Date(2026, 1, 4, 23, 0, 0, 0, time.UTC), time.Monday, "2025-12-22"},
+		{"monday jan 5 2026", time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), time.Monday, "2025-12-29"},
+		{"sunday start, jan 3 2026", time.Date(2026, 1, 3, 12, 0, 0, 0, time.UTC), time.Sunday, "2025-12-21"},
+		{"sunday start, jan 4 2026", time.Date(2026, 1, 4, 12, 0, 0, 0, time.UTC), time.Sunday, "2025-12-28"},
+		{"leap day 2028", time.Date(2028, 2, 29, 12, 0, 0, 0, time.UTC), time.Monday, "2028-02-21"},
+		{"monday mar 6 2028", time.Date(2028, 3, 6, 12, 0, 0, 0, time.UTC), time.Monday, "2028-02-28"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setClock(t, tt.now, tt.start, time.UTC)
+			if got := getLastCompletedWeekStart(); got != tt.want {
+				t.Errorf("getLastCompletedWeekStart() at %s = %s, want %s", tt.now.Format(time.RFC3339), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetLastCompletedWeekStartTimezone(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	losAngeles, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	// Late Sunday UTC is already Monday in Tokyo but still Sunday afternoon
+	// in Los Angeles.
+	lateSunday := time.Date(2025, 10, 19, 23, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		loc  *time.Location
+		want string
+	}{
+		{"utc", time.UTC, "2025-10-06"},
+		{"tokyo", tokyo, "2025-10-13"},
+		{"los angeles", losAngeles, "2025-10-06"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setClock(t, lateSunday, time.Monday, tt.loc)
+			if got := getLastCompletedWeekStart(); got != tt.want {
+				t.Errorf("getLastCompletedWeekStart() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	// The Monday after Los Angeles leaves daylight saving time.
+	setClock(t, time.Date(2025, 11, 3, 8, 30, 0, 0, time.UTC), time.Monday, losAngeles)
+	if got := getLastCompletedWeekStart(); got != "2025-10-27" {
+		t.Errorf("getLastCompletedWeekStart() after DST change = %s, want 2025-10-27", got)
+	}
+}
+
+func TestGetLastNWeeks(t *testing.T) {
+	setClock(t, time.Date(2026, 1, 7, 12, 0, 0, 0, time.UTC), time.Monday, time.UTC)
+	want := []string{"2025-12-15", "2025-12-22", "2025-12-29"}
+	got := getLastNWeeks(3)
+	if len(got) != len(want) {
+		t.Fatalf("getLastNWeeks(3) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("getLastNWeeks(3) = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestResolveWeeks(t *testing.T) {
+	// Wednesday Jan 7, 2026: the last completed week began Dec 29.
+	setClock(t, time.Date(2026, 1, 7, 12, 0, 0, 0, time.UTC), time.Monday, time.UTC)
+	tests := []struct {
+		since, until string
+		want         []string
+	}{
+		{"", "", []string{"2025-12-22", "2025-12-29"}},
+		{"now-2w", "", []string{"2025-12-22", "2025-12-29"}},
+		{"now-10d", "last-week", []string{"2025-12-22", "2025-12-29"}},
+		{"2025-12-20", "2025-12-24", []string{"2025-12-15", "2025-12-22"}},
+		{"this-week", "", nil},
+	}
+	for _, tt := range tests {
+		got, err := resolveWeeks(tt.since, tt.until, 2)
+		if tt.want == nil {
+			if err == nil {
+				t.Errorf("resolveWeeks(%q, %q) = %v, want an error", tt.since, tt.until, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("resolveWeeks(%q, %q): %v", tt.since, tt.until, err)
+			continue
+		}
+		if len(got) != len(tt.want) || got[0] != tt.want[0] || got[len(got)-1] != tt.want[len(tt.want)-1] {
+			t.Errorf("resolveWeeks(%q, %q) = %v, want %v", tt.since, tt.until, got, tt.want)
+		}
+	}
+}